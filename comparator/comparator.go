@@ -0,0 +1,92 @@
+package comparator
+
+import "cmp"
+
+// Func is a comparator that reports whether t1 should be ordered before t2
+//
+// it is the comparator model used across the library, for example
+// by heap.NewHeap, where it defines the heap property
+type Func[T any] func(t1, t2 T) bool
+
+// Compare converts the comparator to a three-way comparison function, returning
+// -1 if t1 is ordered before t2, +1 if t2 is ordered before t1 and 0 otherwise
+//
+// the result can be used with slices.SortFunc and similar functions
+func (f Func[T]) Compare(t1, t2 T) int {
+	if f(t1, t2) {
+		return -1
+	}
+	if f(t2, t1) {
+		return 1
+	}
+
+	return 0
+}
+
+// Less reports whether t1 is less than t2, it orders values in ascending order
+//
+// Example usage:
+// - Min-Heap: heap.NewHeap(comparator.Less[int], 3, 1, 2)
+func Less[T cmp.Ordered](t1, t2 T) bool {
+	return cmp.Less(t1, t2)
+}
+
+// Greater reports whether t1 is greater than t2, it orders values in descending order
+//
+// Example usage:
+// - Max-Heap: heap.NewHeap(comparator.Greater[int], 3, 1, 2)
+func Greater[T cmp.Ordered](t1, t2 T) bool {
+	return cmp.Less(t2, t1)
+}
+
+// By returns a comparator that orders values in ascending order of the key extracted by the input function
+//
+// Example usage:
+// - comparator.By(func(u User) int { return u.Age })
+func By[T any, K cmp.Ordered](key func(T) K) Func[T] {
+	return func(t1, t2 T) bool {
+		return cmp.Less(key(t1), key(t2))
+	}
+}
+
+// Reverse returns a comparator that orders values in the reverse order of the input comparator
+func Reverse[T any](comparator Func[T]) Func[T] {
+	return func(t1, t2 T) bool {
+		return comparator(t2, t1)
+	}
+}
+
+// FromCompare converts a three-way comparison function, such as cmp.Compare
+// or strings.Compare, to a comparator
+func FromCompare[T any](compare func(t1, t2 T) int) Func[T] {
+	return func(t1, t2 T) bool {
+		return compare(t1, t2) < 0
+	}
+}
+
+// ThenBy returns a comparator that orders values by the first comparator, and breaks
+// its ties with the next comparators in the given order
+//
+// Example usage:
+// - comparator.ThenBy(comparator.By(func(u User) int { return u.Age }), comparator.By(func(u User) string { return u.Name }))
+func ThenBy[T any](first Func[T], next ...Func[T]) Func[T] {
+	return func(t1, t2 T) bool {
+		if first(t1, t2) {
+			return true
+		}
+		if first(t2, t1) {
+			return false
+		}
+
+		for _, comparator := range next {
+			if comparator(t1, t2) {
+				return true
+			}
+			if comparator(t2, t1) {
+				return false
+			}
+		}
+
+		return false
+	}
+}
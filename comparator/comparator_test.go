@@ -0,0 +1,104 @@
+package comparator
+
+import (
+	"cmp"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// person is used to test the comparators built from keys
+type person struct {
+	name string
+	age  int
+}
+
+var people = []person{
+	{name: "carol", age: 30},
+	{name: "alice", age: 25},
+	{name: "dave", age: 25},
+	{name: "bob", age: 30},
+}
+
+func TestLess_Greater(t *testing.T) {
+	require.True(t, Less(1, 2))
+	require.False(t, Less(2, 1))
+	require.False(t, Less(1, 1))
+	require.True(t, Less("a", "b"))
+
+	require.True(t, Greater(2, 1))
+	require.False(t, Greater(1, 2))
+	require.False(t, Greater(1, 1))
+	require.True(t, Greater(2.5, 1.5))
+}
+
+func TestFunc_Compare(t *testing.T) {
+	compare := Func[int](Less[int]).Compare
+
+	require.Equal(t, -1, compare(1, 2))
+	require.Equal(t, 1, compare(2, 1))
+	require.Equal(t, 0, compare(1, 1))
+
+	values := []int{3, 1, 2}
+	slices.SortFunc(values, Func[int](Greater[int]).Compare)
+	require.Equal(t, []int{3, 2, 1}, values)
+}
+
+func TestBy(t *testing.T) {
+	byAge := By(func(p person) int { return p.age })
+
+	require.True(t, byAge(people[1], people[0]))
+	require.False(t, byAge(people[0], people[1]))
+	require.False(t, byAge(people[1], people[2]))
+}
+
+func TestReverse(t *testing.T) {
+	require.True(t, Reverse(Less[int])(2, 1))
+	require.False(t, Reverse(Less[int])(1, 2))
+	require.False(t, Reverse(Less[int])(1, 1))
+
+	// reversing a plain function literal
+	reversed := Reverse(func(t1, t2 string) bool { return len(t1) < len(t2) })
+	require.True(t, reversed("abc", "a"))
+}
+
+func TestFromCompare(t *testing.T) {
+	require.True(t, FromCompare(strings.Compare)("a", "b"))
+	require.False(t, FromCompare(strings.Compare)("b", "a"))
+	require.False(t, FromCompare(cmp.Compare[int])(1, 1))
+}
+
+func TestThenBy(t *testing.T) {
+	byAgeThenName := ThenBy(
+		By(func(p person) int { return p.age }),
+		By(func(p person) string { return p.name }),
+	)
+
+	sorted := slices.Clone(people)
+	slices.SortFunc(sorted, byAgeThenName.Compare)
+	require.Equal(t, []person{
+		{name: "alice", age: 25},
+		{name: "dave", age: 25},
+		{name: "bob", age: 30},
+		{name: "carol", age: 30},
+	}, sorted)
+
+	byAgeDescendingThenName := ThenBy(
+		Reverse(By(func(p person) int { return p.age })),
+		By(func(p person) string { return p.name }),
+	)
+
+	slices.SortFunc(sorted, byAgeDescendingThenName.Compare)
+	require.Equal(t, []person{
+		{name: "bob", age: 30},
+		{name: "carol", age: 30},
+		{name: "alice", age: 25},
+		{name: "dave", age: 25},
+	}, sorted)
+
+	// without tie breakers ThenBy behaves like the first comparator
+	byAge := ThenBy(By(func(p person) int { return p.age }))
+	require.False(t, byAge(people[1], people[2]))
+	require.False(t, byAge(people[2], people[1]))
+}
@@ -0,0 +1,314 @@
+package deque
+
+import (
+	"iter"
+	"slices"
+)
+
+// chunkSize is the number of elements stored in each chunk of a chunked deque
+const chunkSize = 64
+
+// chunkedDeque is a struct representing a generic double-ended queue data structure
+// implemented with a circular array split into fixed size chunks.
+//
+// The chunks form a single circular buffer of len(chunks)*chunkSize elements, so the
+// element at index i of the deque is stored at position (start+i) of the buffer.
+// Growing the buffer only copies the chunk references, never the elements themselves.
+type chunkedDeque[T any] struct {
+	// chunks is the list of chunks in buffer order.
+	chunks [][]T
+
+	// start is the position of the front element in the buffer.
+	start int
+
+	// size is the number of elements in the deque.
+	size int
+}
+
+// capacity returns the number of elements the buffer can hold
+func (d *chunkedDeque[T]) capacity() int {
+	return len(d.chunks) * chunkSize
+}
+
+// slot returns a pointer to the buffer slot at the given position
+//
+// does not check for the validity of the position, should be checked at the caller
+func (d *chunkedDeque[T]) slot(position int) *T {
+	position %= d.capacity()
+	return &d.chunks[position/chunkSize][position%chunkSize]
+}
+
+// usedChunks returns the number of chunks spanned by the elements, counted from the
+// chunk holding the front element
+//
+// it can be one more than the number of chunks when the buffer is full and the front
+// element is not at the start of its chunk, since the last elements then wrap around
+// into the first chunk
+func (d *chunkedDeque[T]) usedChunks() int {
+	if d.size == 0 {
+		return 0
+	}
+
+	return (d.start%chunkSize+d.size-1)/chunkSize + 1
+}
+
+// resize reorders the chunks so the front element is in the first chunk and changes
+// the number of chunks to the given count, allocating or releasing chunks as needed
+//
+// count should be at least the number of used chunks, should be checked at the caller
+// O(number of chunks), plus O(chunkSize) if the elements wrap around into their first chunk
+func (d *chunkedDeque[T]) resize(count int) {
+	chunks := make([][]T, count)
+
+	first := d.start / chunkSize
+	used := d.usedChunks()
+
+	for i := 0; i < used && i < len(d.chunks); i++ {
+		chunks[i] = d.chunks[(first+i)%len(d.chunks)]
+	}
+
+	// the elements that wrapped around into the first chunk are moved into a chunk of their own
+	if used > len(d.chunks) {
+		var zero T
+
+		wrapped := d.chunks[first]
+		end := d.start%chunkSize + d.size - len(d.chunks)*chunkSize
+
+		chunks[used-1] = make([]T, chunkSize)
+		copy(chunks[used-1], wrapped[:end])
+		for i := 0; i < end; i++ {
+			wrapped[i] = zero
+		}
+	}
+
+	for i := used; i < count; i++ {
+		chunks[i] = make([]T, chunkSize)
+	}
+
+	d.chunks = chunks
+	d.start %= chunkSize
+}
+
+// grow doubles the number of chunks if the buffer is full
+func (d *chunkedDeque[T]) grow() {
+	if d.size == d.capacity() {
+		d.resize(2 * len(d.chunks))
+	}
+}
+
+// shrink halves the number of chunks if the buffer is only a quarter full
+// and the elements fit in half of the chunks
+func (d *chunkedDeque[T]) shrink() {
+	half := len(d.chunks) / 2
+	if half >= 1 && d.size <= d.capacity()/4 && d.usedChunks() <= half {
+		d.resize(half)
+	}
+}
+
+// Size returns the number of elements in the deque.
+func (d *chunkedDeque[T]) Size() int {
+	return d.size
+}
+
+// PushFront adds an element to the front of the deque.
+//
+// amortized O(1)
+func (d *chunkedDeque[T]) PushFront(t T) {
+	d.grow()
+
+	d.start = (d.start - 1 + d.capacity()) % d.capacity()
+	*d.slot(d.start) = t
+	d.size += 1
+}
+
+// PushBack adds an element to the back of the deque.
+//
+// amortized O(1)
+func (d *chunkedDeque[T]) PushBack(t T) {
+	d.grow()
+
+	*d.slot(d.start + d.size) = t
+	d.size += 1
+}
+
+// PopFront removes and returns the element at the front of the deque.
+//
+// It returns the front element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+// amortized O(1)
+func (d *chunkedDeque[T]) PopFront() (t T, ok bool) {
+	if d.size == 0 {
+		return
+	}
+
+	var zero T
+	slot := d.slot(d.start)
+	t = *slot
+
+	// clear the reference to help garbage collection
+	*slot = zero
+
+	d.start = (d.start + 1) % d.capacity()
+	d.size -= 1
+	d.shrink()
+
+	return t, true
+}
+
+// PopBack removes and returns the element at the back of the deque.
+//
+// It returns the back element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+// amortized O(1)
+func (d *chunkedDeque[T]) PopBack() (t T, ok bool) {
+	if d.size == 0 {
+		return
+	}
+
+	var zero T
+	slot := d.slot(d.start + d.size - 1)
+	t = *slot
+
+	// clear the reference to help garbage collection
+	*slot = zero
+
+	d.size -= 1
+	d.shrink()
+
+	return t, true
+}
+
+// PeekFront returns the element at the front of the deque without removing it.
+//
+// It returns the front element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (d *chunkedDeque[T]) PeekFront() (t T, ok bool) {
+	if d.size == 0 {
+		return
+	}
+
+	return *d.slot(d.start), true
+}
+
+// PeekBack returns the element at the back of the deque without removing it.
+//
+// It returns the back element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (d *chunkedDeque[T]) PeekBack() (t T, ok bool) {
+	if d.size == 0 {
+		return
+	}
+
+	return *d.slot(d.start + d.size - 1), true
+}
+
+// At returns the element at the given index, counted from the front of the deque.
+//
+// ok = false means the index is out of range.
+// O(1)
+func (d *chunkedDeque[T]) At(index int) (t T, ok bool) {
+	if index < 0 || index >= d.size {
+		return
+	}
+
+	return *d.slot(d.start + index), true
+}
+
+// Rotate rotates the deque n steps front to back: the first n elements are moved
+// to the back of the deque. A negative n rotates the deque back to front.
+//
+// O(1) if the buffer is full, otherwise the elements are moved in the shorter
+// direction, O(min(n, size-n))
+func (d *chunkedDeque[T]) Rotate(n int) {
+	steps := rotationSteps(n, d.size)
+	if steps == 0 {
+		return
+	}
+
+	// when the buffer is full, the front and back are adjacent, so moving the start is enough
+	if d.size == d.capacity() {
+		d.start = ((d.start+steps)%d.capacity() + d.capacity()) % d.capacity()
+		return
+	}
+
+	var zero T
+	for ; steps > 0; steps-- {
+		front := d.slot(d.start)
+		*d.slot(d.start + d.size) = *front
+		*front = zero
+		d.start = (d.start + 1) % d.capacity()
+	}
+	for ; steps < 0; steps++ {
+		back := d.slot(d.start + d.size - 1)
+		d.start = (d.start - 1 + d.capacity()) % d.capacity()
+		*d.slot(d.start) = *back
+		*back = zero
+	}
+}
+
+// All returns an iterator over the elements of the deque, from the front to the back.
+//
+// The deque should not be modified during the iteration.
+func (d *chunkedDeque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(*d.slot(d.start + i)) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a new slice holding the elements of the deque, from the front to the back.
+//
+// O(n)
+func (d *chunkedDeque[T]) ToSlice() []T {
+	return d.AppendTo(make([]T, 0, d.size))
+}
+
+// AppendTo appends the elements of the deque to dst, from the front to the back,
+// and returns the extended slice.
+//
+// the elements are copied a chunk at a time
+// O(n)
+func (d *chunkedDeque[T]) AppendTo(dst []T) []T {
+	dst = slices.Grow(dst, d.size)
+
+	for position, remaining := d.start, d.size; remaining > 0; {
+		position %= d.capacity()
+		chunk := d.chunks[position/chunkSize][position%chunkSize:]
+		n := min(len(chunk), remaining)
+
+		dst = append(dst, chunk[:n]...)
+		position += n
+		remaining -= n
+	}
+
+	return dst
+}
+
+// replace replaces the elements of the deque with the input values, pushed to the back in order
+//
+// the values are copied straight into as many new chunks as they need, and the slice
+// is not referenced by the deque
+// O(m)
+func (d *chunkedDeque[T]) replace(values []T) {
+	d.chunks = make([][]T, max((len(values)+chunkSize-1)/chunkSize, 1))
+	for i := range d.chunks {
+		d.chunks[i] = make([]T, chunkSize)
+		copy(d.chunks[i], values[min(i*chunkSize, len(values)):])
+	}
+
+	d.start = 0
+	d.size = len(values)
+}
+
+// NewChunkedDeque creates and returns a new deque implemented with a circular array
+// of fixed size chunks.
+func NewChunkedDeque[T any]() Deque[T] {
+	return &chunkedDeque[T]{
+		chunks: [][]T{make([]T, chunkSize)},
+		start:  0,
+		size:   0,
+	}
+}
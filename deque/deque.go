@@ -0,0 +1,146 @@
+package deque
+
+import (
+	"github.com/TheFeij/go-collections/linkedlist"
+	"iter"
+)
+
+// deque is a struct representing a generic double-ended queue data structure.
+type deque[T any] struct {
+	// list is the underlying linkedlist used to implement the deque.
+	list linkedlist.DoublyLinkedList[T]
+}
+
+// Size returns the number of elements in the deque.
+func (d *deque[T]) Size() int {
+	return d.list.Size()
+}
+
+// PushFront adds an element to the front of the deque.
+func (d *deque[T]) PushFront(t T) {
+	d.list.AddFirst(t)
+}
+
+// PushBack adds an element to the back of the deque.
+func (d *deque[T]) PushBack(t T) {
+	d.list.AddLast(t)
+}
+
+// PopFront removes and returns the element at the front of the deque.
+//
+// It returns the front element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (d *deque[T]) PopFront() (t T, ok bool) {
+	t, ok = d.list.GetFirst()
+	if !ok {
+		return
+	}
+
+	d.list.DeleteFirst()
+	return
+}
+
+// PopBack removes and returns the element at the back of the deque.
+//
+// It returns the back element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (d *deque[T]) PopBack() (t T, ok bool) {
+	t, ok = d.list.GetLast()
+	if !ok {
+		return
+	}
+
+	d.list.DeleteLast()
+	return
+}
+
+// PeekFront returns the element at the front of the deque without removing it.
+//
+// It returns the front element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (d *deque[T]) PeekFront() (t T, ok bool) {
+	return d.list.GetFirst()
+}
+
+// PeekBack returns the element at the back of the deque without removing it.
+//
+// It returns the back element and ok = true if the deque is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (d *deque[T]) PeekBack() (t T, ok bool) {
+	return d.list.GetLast()
+}
+
+// At returns the element at the given index, counted from the front of the deque.
+//
+// ok = false means the index is out of range.
+// worst case O(n/2)
+func (d *deque[T]) At(index int) (t T, ok bool) {
+	return d.list.Get(index)
+}
+
+// Rotate rotates the deque n steps front to back: the first n elements are moved
+// to the back of the deque. A negative n rotates the deque back to front.
+//
+// the elements are moved in the shorter direction, O(min(n, size-n))
+func (d *deque[T]) Rotate(n int) {
+	steps := rotationSteps(n, d.list.Size())
+
+	if steps > 0 {
+		for i := 0; i < steps; i++ {
+			d.list.MoveToBack(d.list.Front())
+		}
+	} else {
+		for i := 0; i < -steps; i++ {
+			d.list.MoveToFront(d.list.Back())
+		}
+	}
+}
+
+// All returns an iterator over the elements of the deque, from the front to the back.
+//
+// The iterator panics if the deque is modified during the iteration.
+func (d *deque[T]) All() iter.Seq[T] {
+	return d.list.Values()
+}
+
+// ToSlice returns a new slice holding the elements of the deque, from the front to the back.
+//
+// O(n)
+func (d *deque[T]) ToSlice() []T {
+	return d.list.ToSlice()
+}
+
+// AppendTo appends the elements of the deque to dst, from the front to the back,
+// and returns the extended slice.
+//
+// O(n)
+func (d *deque[T]) AppendTo(dst []T) []T {
+	return d.list.AppendTo(dst)
+}
+
+// rotationSteps reduces a rotation of n steps front to back on a deque of the given size
+// to the equivalent rotation with the fewest steps, negative meaning back to front
+func rotationSteps(n, size int) int {
+	if size <= 1 {
+		return 0
+	}
+
+	n %= size
+	if n < 0 {
+		n += size
+	}
+
+	if n > size/2 {
+		return n - size
+	}
+
+	return n
+}
+
+// NewDeque creates and returns a new deque.
+// It initializes the underlying linked list with a new doubly linked list.
+func NewDeque[T any]() Deque[T] {
+	return &deque[T]{
+		list: linkedlist.NewDoublyLinkedList[T](),
+	}
+}
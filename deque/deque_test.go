@@ -0,0 +1,469 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// deques is the list of deque implementations that the conformance tests run against
+var deques = []struct {
+	name     string
+	newDeque func() Deque[int]
+}{
+	{
+		name:     "linked list deque",
+		newDeque: NewDeque[int],
+	},
+	{
+		name:     "chunked deque",
+		newDeque: NewChunkedDeque[int],
+	},
+}
+
+// requireContent asserts that the deque contains exactly the expected elements, in order
+func requireContent(t *testing.T, d Deque[int], expected []int) {
+	t.Helper()
+
+	require.Equal(t, len(expected), d.Size())
+	for index, number := range expected {
+		value, ok := d.At(index)
+		require.True(t, ok)
+		require.Equal(t, number, value)
+	}
+}
+
+func TestNewDeque(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			d := deque.newDeque()
+
+			require.NotNil(t, d)
+			require.Equal(t, 0, d.Size())
+
+			value, ok := d.PeekFront()
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			value, ok = d.PeekBack()
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			value, ok = d.PopFront()
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			value, ok = d.PopBack()
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			value, ok = d.At(0)
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			d.Rotate(3)
+			require.Equal(t, 0, d.Size())
+		})
+	}
+}
+
+func TestDeque_Push_Pop(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			t.Run("PushBack PopFront", func(t *testing.T) {
+				d := deque.newDeque()
+
+				const numberOfElements = 1000
+				for i := 0; i < numberOfElements; i++ {
+					d.PushBack(i)
+					require.Equal(t, i+1, d.Size())
+
+					value, ok := d.PeekBack()
+					require.True(t, ok)
+					require.Equal(t, i, value)
+				}
+
+				for i := 0; i < numberOfElements; i++ {
+					value, ok := d.PeekFront()
+					require.True(t, ok)
+					require.Equal(t, i, value)
+
+					value, ok = d.PopFront()
+					require.True(t, ok)
+					require.Equal(t, i, value)
+					require.Equal(t, numberOfElements-i-1, d.Size())
+				}
+			})
+			t.Run("PushFront PopBack", func(t *testing.T) {
+				d := deque.newDeque()
+
+				const numberOfElements = 1000
+				for i := 0; i < numberOfElements; i++ {
+					d.PushFront(i)
+					require.Equal(t, i+1, d.Size())
+
+					value, ok := d.PeekFront()
+					require.True(t, ok)
+					require.Equal(t, i, value)
+				}
+
+				for i := 0; i < numberOfElements; i++ {
+					value, ok := d.PopBack()
+					require.True(t, ok)
+					require.Equal(t, i, value)
+				}
+
+				_, ok := d.PopBack()
+				require.False(t, ok)
+			})
+			t.Run("Both Ends", func(t *testing.T) {
+				d := deque.newDeque()
+
+				d.PushBack(2)
+				d.PushFront(1)
+				d.PushBack(3)
+				d.PushFront(0)
+				requireContent(t, d, []int{0, 1, 2, 3})
+
+				value, ok := d.PopBack()
+				require.True(t, ok)
+				require.Equal(t, 3, value)
+
+				value, ok = d.PopFront()
+				require.True(t, ok)
+				require.Equal(t, 0, value)
+
+				requireContent(t, d, []int{1, 2})
+			})
+			t.Run("Grow While Wrapped Around", func(t *testing.T) {
+				d := deque.newDeque()
+
+				// fill the buffer with the front element in the middle of it, then grow
+				var expected []int
+				for i := 0; i < chunkSize/2; i++ {
+					d.PushFront(-i)
+					expected = append([]int{-i}, expected...)
+				}
+				for i := 1; i <= 2*chunkSize; i++ {
+					d.PushBack(i)
+					expected = append(expected, i)
+				}
+				requireContent(t, d, expected)
+
+				for len(expected) > 0 {
+					value, ok := d.PopFront()
+					require.True(t, ok)
+					require.Equal(t, expected[0], value)
+					expected = expected[1:]
+					requireContent(t, d, expected)
+				}
+			})
+		})
+	}
+}
+
+func TestDeque_At(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			d := deque.newDeque()
+
+			const numberOfElements = 200
+			for i := 0; i < numberOfElements; i++ {
+				d.PushBack(i)
+			}
+
+			for i := 0; i < numberOfElements; i++ {
+				value, ok := d.At(i)
+				require.True(t, ok)
+				require.Equal(t, i, value)
+			}
+
+			value, ok := d.At(-1)
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			value, ok = d.At(numberOfElements)
+			require.False(t, ok)
+			require.Zero(t, value)
+		})
+	}
+}
+
+func TestDeque_Rotate(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			newDeque := func(size int) Deque[int] {
+				d := deque.newDeque()
+				for i := 0; i < size; i++ {
+					d.PushBack(i)
+				}
+				return d
+			}
+
+			d := newDeque(5)
+			d.Rotate(2)
+			requireContent(t, d, []int{2, 3, 4, 0, 1})
+
+			d.Rotate(-2)
+			requireContent(t, d, []int{0, 1, 2, 3, 4})
+
+			d.Rotate(4)
+			requireContent(t, d, []int{4, 0, 1, 2, 3})
+
+			d.Rotate(-6)
+			requireContent(t, d, []int{3, 4, 0, 1, 2})
+
+			d.Rotate(10)
+			requireContent(t, d, []int{3, 4, 0, 1, 2})
+
+			d = newDeque(1)
+			d.Rotate(7)
+			requireContent(t, d, []int{0})
+
+			// rotating a deque that fills its whole buffer
+			for _, size := range []int{chunkSize, 2 * chunkSize, 3*chunkSize + 1} {
+				d = newDeque(size)
+				expected := make([]int, size)
+				for i := range expected {
+					expected[i] = (i + 3) % size
+				}
+
+				d.Rotate(3)
+				requireContent(t, d, expected)
+			}
+		})
+	}
+}
+
+func TestDeque_Randomized(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+			d := deque.newDeque()
+
+			// the expected content of the deque is kept in a slice
+			var expected []int
+			for i := 0; i < 20000; i++ {
+				// favour pushes in the first half and pops in the second half,
+				// so the deque grows and shrinks across many chunks
+				pushBias := 6
+				if i >= 10000 {
+					pushBias = 4
+				}
+
+				switch operation := random.Intn(10); {
+				case operation < pushBias/2:
+					d.PushFront(i)
+					expected = append([]int{i}, expected...)
+				case operation < pushBias:
+					d.PushBack(i)
+					expected = append(expected, i)
+				case operation < 7:
+					value, ok := d.PopFront()
+					require.Equal(t, len(expected) > 0, ok)
+					if ok {
+						require.Equal(t, expected[0], value)
+						expected = expected[1:]
+					}
+				case operation < 9:
+					value, ok := d.PopBack()
+					require.Equal(t, len(expected) > 0, ok)
+					if ok {
+						require.Equal(t, expected[len(expected)-1], value)
+						expected = expected[:len(expected)-1]
+					}
+				default:
+					n := random.Intn(200) - 100
+					d.Rotate(n)
+					if size := len(expected); size > 0 {
+						steps := ((n % size) + size) % size
+						expected = append(expected[steps:], expected[:steps]...)
+					}
+				}
+
+				require.Equal(t, len(expected), d.Size())
+				if len(expected) > 0 {
+					front, ok := d.PeekFront()
+					require.True(t, ok)
+					require.Equal(t, expected[0], front)
+
+					back, ok := d.PeekBack()
+					require.True(t, ok)
+					require.Equal(t, expected[len(expected)-1], back)
+				}
+			}
+
+			requireContent(t, d, expected)
+		})
+	}
+}
+
+func TestDeque_All(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			d := deque.newDeque()
+			require.Empty(t, slices.Collect(d.All()))
+
+			// push to both ends and rotate, so the chunked deque wraps around its buffer
+			for i := 0; i < 100; i++ {
+				d.PushBack(i)
+			}
+			for i := -1; i >= -100; i-- {
+				d.PushFront(i)
+			}
+			d.Rotate(150)
+
+			expected := make([]int, 0, 200)
+			for i := 50; i < 100; i++ {
+				expected = append(expected, i)
+			}
+			for i := -100; i < 50; i++ {
+				expected = append(expected, i)
+			}
+
+			require.Equal(t, expected, slices.Collect(d.All()))
+			requireContent(t, d, expected)
+
+			var values []int
+			for value := range d.All() {
+				if len(values) == 2 {
+					break
+				}
+				values = append(values, value)
+			}
+			require.Equal(t, expected[:2], values)
+		})
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	d := FromSeq(slices.Values([]int{1, 2, 3}))
+	requireContent(t, d, []int{1, 2, 3})
+
+	require.Zero(t, FromSeq(slices.Values([]int{})).Size())
+}
+
+func TestDeque_ToSlice(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			d := deque.newDeque()
+			require.Equal(t, []int{}, d.ToSlice())
+			require.Nil(t, d.AppendTo(nil))
+
+			// push to both ends and rotate, so the chunked deque wraps around its buffer
+			for i := 0; i < 100; i++ {
+				d.PushBack(i)
+			}
+			for i := -1; i >= -100; i-- {
+				d.PushFront(i)
+			}
+			d.Rotate(150)
+
+			expected := slices.Collect(d.All())
+			require.Equal(t, expected, d.ToSlice())
+			require.Equal(t, append([]int{-1000}, expected...), d.AppendTo([]int{-1000}))
+			requireContent(t, d, expected)
+		})
+	}
+}
+
+func TestFrom_FromSlice(t *testing.T) {
+	requireContent(t, From(1, 2, 3), []int{1, 2, 3})
+	require.Zero(t, From[int]().Size())
+	require.Zero(t, FromSlice[int](nil).Size())
+
+	// sizes around the chunk boundaries
+	for _, size := range []int{1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize} {
+		data := make([]int, size)
+		for i := range data {
+			data[i] = i
+		}
+
+		d := FromSlice(data)
+		requireContent(t, d, data)
+		require.Equal(t, data, d.ToSlice())
+
+		// the deque keeps working at both ends
+		d.PushFront(-1)
+		d.PushBack(size)
+		requireContent(t, d, append(append([]int{-1}, data...), size))
+
+		for i := -1; i <= size; i++ {
+			value, ok := d.PopFront()
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+		require.Zero(t, d.Size())
+	}
+
+	// the input slice is copied
+	data := []int{1, 2, 3}
+	d := FromSlice(data)
+	data[0] = 10
+	requireContent(t, d, []int{1, 2, 3})
+}
+
+func TestDeque_Encoding(t *testing.T) {
+	for _, deque := range deques {
+		t.Run(deque.name, func(t *testing.T) {
+			// push to both ends, so the chunked deque wraps around its buffer
+			d := deque.newDeque()
+			for i := 0; i < 100; i++ {
+				d.PushBack(i)
+			}
+			for i := -1; i >= -100; i-- {
+				d.PushFront(i)
+			}
+			expected := d.ToSlice()
+
+			data, err := json.Marshal(d)
+			require.NoError(t, err)
+
+			decoded := deque.newDeque()
+			decoded.PushBack(1000)
+			require.NoError(t, json.Unmarshal(data, decoded))
+			requireContent(t, decoded, expected)
+
+			// null and invalid data leave the deque unchanged
+			require.NoError(t, json.Unmarshal([]byte(`null`), decoded))
+			require.Error(t, json.Unmarshal([]byte(`[1.5]`), decoded))
+			requireContent(t, decoded, expected)
+
+			var buffer bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buffer).Encode(d))
+			decoded = deque.newDeque()
+			require.NoError(t, gob.NewDecoder(&buffer).Decode(decoded))
+			requireContent(t, decoded, expected)
+
+			binary, err := d.MarshalBinary()
+			require.NoError(t, err)
+			decoded = deque.newDeque()
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			requireContent(t, decoded, expected)
+			require.Error(t, decoded.UnmarshalBinary([]byte("invalid")))
+			requireContent(t, decoded, expected)
+
+			// the decoded deque keeps working at both ends
+			decoded.PushFront(-101)
+			decoded.PushBack(100)
+			value, ok := decoded.PopFront()
+			require.True(t, ok)
+			require.Equal(t, -101, value)
+			value, ok = decoded.PopBack()
+			require.True(t, ok)
+			require.Equal(t, 100, value)
+
+			// an empty deque encodes to an empty one
+			binary, err = deque.newDeque().MarshalBinary()
+			require.NoError(t, err)
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			require.Zero(t, decoded.Size())
+			decoded.PushFront(1)
+			requireContent(t, decoded, []int{1})
+		})
+	}
+}
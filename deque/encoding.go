@@ -0,0 +1,107 @@
+package deque
+
+import "github.com/TheFeij/go-collections/internal/serial"
+
+// The deques are serialized as the sequence of their elements, from the front to the back.
+
+// MarshalJSON encodes the deque as a JSON array of its elements, from the front to the back
+func (d *deque[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(d.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the deque with the elements of a JSON array, from the front to the back
+//
+// the deque is left unchanged if data is invalid or is the JSON null
+func (d *deque[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	d.replace(values)
+	return nil
+}
+
+// GobEncode encodes the deque as a gob stream of its elements, from the front to the back
+func (d *deque[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(d.ToSlice())
+}
+
+// GobDecode replaces the elements of the deque with the elements of a gob stream written by GobEncode
+//
+// the deque is left unchanged if data is invalid
+func (d *deque[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	d.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the deque in binary form, which is the same as GobEncode
+func (d *deque[T]) MarshalBinary() ([]byte, error) {
+	return d.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (d *deque[T]) UnmarshalBinary(data []byte) error {
+	return d.GobDecode(data)
+}
+
+// replace replaces the elements of the deque with the input values, pushed to the back in order
+//
+// O(n + m)
+func (d *deque[T]) replace(values []T) {
+	d.list.Clear()
+	for _, t := range values {
+		d.list.AddLast(t)
+	}
+}
+
+// MarshalJSON encodes the deque as a JSON array of its elements, from the front to the back
+func (d *chunkedDeque[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(d.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the deque with the elements of a JSON array, from the front to the back
+//
+// the deque is left unchanged if data is invalid or is the JSON null
+func (d *chunkedDeque[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	d.replace(values)
+	return nil
+}
+
+// GobEncode encodes the deque as a gob stream of its elements, from the front to the back
+func (d *chunkedDeque[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(d.ToSlice())
+}
+
+// GobDecode replaces the elements of the deque with the elements of a gob stream written by GobEncode
+//
+// the deque is left unchanged if data is invalid
+func (d *chunkedDeque[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	d.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the deque in binary form, which is the same as GobEncode
+func (d *chunkedDeque[T]) MarshalBinary() ([]byte, error) {
+	return d.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (d *chunkedDeque[T]) UnmarshalBinary(data []byte) error {
+	return d.GobDecode(data)
+}
@@ -0,0 +1,36 @@
+package deque
+
+import "iter"
+
+// FromSeq creates and returns a new deque holding the elements of the input sequence,
+// pushed to the back in order, so the first element of the sequence is at the front.
+//
+// The deque is implemented with a chunked circular array, as the one returned by NewChunkedDeque.
+func FromSeq[T any](seq iter.Seq[T]) Deque[T] {
+	d := NewChunkedDeque[T]()
+	for t := range seq {
+		d.PushBack(t)
+	}
+
+	return d
+}
+
+// From creates and returns a new deque holding the input values, so the first value is at the front.
+//
+// The deque is implemented with a chunked circular array, as the one returned by NewChunkedDeque.
+func From[T any](values ...T) Deque[T] {
+	return FromSlice(values)
+}
+
+// FromSlice creates and returns a new deque holding the elements of the input slice,
+// so the first element of the slice is at the front.
+//
+// The deque is implemented with a chunked circular array, as the one returned by NewChunkedDeque.
+// The elements are copied straight into as many chunks as they need, and the slice is not
+// referenced by the deque.
+func FromSlice[T any](s []T) Deque[T] {
+	d := &chunkedDeque[T]{}
+	d.replace(s)
+
+	return d
+}
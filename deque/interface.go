@@ -0,0 +1,73 @@
+package deque
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"iter"
+)
+
+// Deque defines the interface for a generic double-ended queue data structure.
+type Deque[T any] interface {
+	// PushFront adds an element to the front of the deque.
+	PushFront(T)
+
+	// PushBack adds an element to the back of the deque.
+	PushBack(T)
+
+	// PopFront removes and returns the element at the front of the deque.
+	//
+	// It returns the front element and ok = true if the deque is not empty,
+	// otherwise it returns the zero value of type T and ok = false.
+	PopFront() (t T, ok bool)
+
+	// PopBack removes and returns the element at the back of the deque.
+	//
+	// It returns the back element and ok = true if the deque is not empty,
+	// otherwise it returns the zero value of type T and ok = false.
+	PopBack() (t T, ok bool)
+
+	// PeekFront returns the element at the front of the deque without removing it.
+	//
+	// It returns the front element and ok = true if the deque is not empty,
+	// otherwise it returns the zero value of type T and ok = false.
+	PeekFront() (t T, ok bool)
+
+	// PeekBack returns the element at the back of the deque without removing it.
+	//
+	// It returns the back element and ok = true if the deque is not empty,
+	// otherwise it returns the zero value of type T and ok = false.
+	PeekBack() (t T, ok bool)
+
+	// At returns the element at the given index, counted from the front of the deque.
+	//
+	// ok = false means the index is out of range.
+	At(index int) (t T, ok bool)
+
+	// Rotate rotates the deque n steps front to back: the first n elements are moved
+	// to the back of the deque. A negative n rotates the deque back to front.
+	Rotate(n int)
+
+	// Size returns the number of elements in the deque.
+	Size() int
+
+	// All returns an iterator over the elements of the deque, from the front to the back,
+	// without removing them.
+	All() iter.Seq[T]
+
+	// ToSlice returns a new slice holding the elements of the deque, from the front to the back.
+	ToSlice() []T
+
+	// AppendTo appends the elements of the deque to dst, from the front to the back,
+	// and returns the extended slice.
+	AppendTo(dst []T) []T
+
+	// The deque is serialized as the sequence of its elements, from the front to
+	// the back, and decoding replaces its elements.
+	json.Marshaler
+	json.Unmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
@@ -0,0 +1,176 @@
+package functional
+
+import "iter"
+
+// Map returns a sequence of the results of applying f to the elements of the input sequence
+//
+// the sequence is lazy, f is applied as the elements are iterated
+func Map[T, U any](seq iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for t := range seq {
+			if !yield(f(t)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence of the elements of the input sequence that satisfy the predicate
+//
+// the sequence is lazy, the predicate is applied as the elements are iterated
+func Filter[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range seq {
+			if predicate(t) && !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce combines the elements of the input sequence into a single value, starting with
+// the initial value and applying f to the accumulated value and each element in order
+//
+// returns the initial value if the sequence is empty
+func Reduce[T, A any](seq iter.Seq[T], initial A, f func(A, T) A) A {
+	accumulated := initial
+	for t := range seq {
+		accumulated = f(accumulated, t)
+	}
+
+	return accumulated
+}
+
+// FlatMap returns a sequence of the elements of the sequences returned by applying f
+// to the elements of the input sequence, in order
+//
+// the sequence is lazy, f is applied as the elements are iterated
+func FlatMap[T, U any](seq iter.Seq[T], f func(T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for t := range seq {
+			for u := range f(t) {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip returns a sequence of pairs of the elements of the input sequences at the same positions
+//
+// the sequence ends with the shorter input sequence
+func Zip[T, U any](a iter.Seq[T], b iter.Seq[U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for t := range a {
+			u, ok := next()
+			if !ok || !yield(t, u) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk returns a sequence of consecutive, non-overlapping chunks of the input sequence
+// with size elements each, the last chunk may have fewer elements
+//
+// every chunk is a new slice, the sequence is empty if size < 1
+func Chunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size < 1 {
+			return
+		}
+
+		chunk := make([]T, 0, size)
+		for t := range seq {
+			chunk = append(chunk, t)
+			if len(chunk) < size {
+				continue
+			}
+
+			if !yield(chunk) {
+				return
+			}
+			chunk = make([]T, 0, size)
+		}
+
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window returns a sequence of the sliding windows of size consecutive elements of the input
+// sequence, each window starting one element after the previous one
+//
+// every window is a new slice, the sequence is empty if size < 1 or the input sequence
+// has fewer than size elements
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size < 1 {
+			return
+		}
+
+		// window holds the last size elements, in order
+		window := make([]T, 0, size)
+		for t := range seq {
+			if len(window) == size {
+				copy(window, window[1:])
+				window[size-1] = t
+			} else {
+				window = append(window, t)
+			}
+
+			if len(window) == size && !yield(append([]T(nil), window...)) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns a sequence of the leading elements of the input sequence that satisfy
+// the predicate, it ends at the first element that does not
+func TakeWhile[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for t := range seq {
+			if !predicate(t) || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile returns a sequence of the elements of the input sequence starting at the first
+// element that does not satisfy the predicate
+func DropWhile[T any](seq iter.Seq[T], predicate func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for t := range seq {
+			if dropping && predicate(t) {
+				continue
+			}
+			dropping = false
+
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// GroupBy groups the elements of the input sequence by the key returned by the key function,
+// the elements of each group keep their order in the sequence
+//
+// unlike the other functions it consumes the whole sequence at once
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for t := range seq {
+		k := key(t)
+		groups[k] = append(groups[k], t)
+	}
+
+	return groups
+}
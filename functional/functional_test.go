@@ -0,0 +1,197 @@
+package functional
+
+import (
+	"github.com/stretchr/testify/require"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// numbers returns a sequence of the integers in [0, n)
+func numbers(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// naturals returns an infinite sequence of the non-negative integers
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// first collects at most n elements of the input sequence
+func first[T any](seq iter.Seq[T], n int) []T {
+	var values []T
+	for t := range seq {
+		if len(values) == n {
+			break
+		}
+		values = append(values, t)
+	}
+
+	return values
+}
+
+func TestMap(t *testing.T) {
+	require.Empty(t, slices.Collect(Map(numbers(0), strconv.Itoa)))
+	require.Equal(t, []string{"0", "1", "2"}, slices.Collect(Map(numbers(3), strconv.Itoa)))
+
+	// lazy over infinite sequences
+	require.Equal(t, []int{0, 2, 4}, first(Map(naturals(), func(i int) int { return 2 * i }), 3))
+}
+
+func TestFilter(t *testing.T) {
+	even := func(i int) bool { return i%2 == 0 }
+
+	require.Empty(t, slices.Collect(Filter(numbers(0), even)))
+	require.Equal(t, []int{0, 2, 4}, slices.Collect(Filter(numbers(6), even)))
+	require.Equal(t, []int{0, 2, 4}, first(Filter(naturals(), even), 3))
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(a, i int) int { return a + i }
+
+	require.Equal(t, 7, Reduce(numbers(0), 7, sum))
+	require.Equal(t, 45, Reduce(numbers(10), 0, sum))
+	require.Equal(t, "012", Reduce(numbers(3), "", func(a string, i int) string {
+		return a + strconv.Itoa(i)
+	}))
+}
+
+func TestFlatMap(t *testing.T) {
+	repeat := func(i int) iter.Seq[int] {
+		return func(yield func(int) bool) {
+			for j := 0; j < i; j++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+
+	require.Empty(t, slices.Collect(FlatMap(numbers(0), repeat)))
+	require.Equal(t, []int{1, 2, 2, 3, 3, 3}, slices.Collect(FlatMap(numbers(4), repeat)))
+	require.Equal(t, []int{1, 2, 2, 3}, first(FlatMap(naturals(), repeat), 4))
+}
+
+func TestZip(t *testing.T) {
+	collect := func(seq iter.Seq2[int, string]) (a []int, b []string) {
+		for i, s := range seq {
+			a = append(a, i)
+			b = append(b, s)
+		}
+		return
+	}
+
+	testCases := []struct {
+		name    string
+		a       iter.Seq[int]
+		b       iter.Seq[string]
+		numbers []int
+		strings []string
+	}{
+		{name: "Empty", a: numbers(0), b: Map(numbers(0), strconv.Itoa), numbers: nil, strings: nil},
+		{name: "Same Length", a: numbers(2), b: slices.Values([]string{"a", "b"}), numbers: []int{0, 1}, strings: []string{"a", "b"}},
+		{name: "Shorter First", a: numbers(1), b: slices.Values([]string{"a", "b"}), numbers: []int{0}, strings: []string{"a"}},
+		{name: "Shorter Second", a: naturals(), b: slices.Values([]string{"a", "b"}), numbers: []int{0, 1}, strings: []string{"a", "b"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			numbers, strings := collect(Zip(tc.a, tc.b))
+			require.Equal(t, tc.numbers, numbers)
+			require.Equal(t, tc.strings, strings)
+		})
+	}
+}
+
+func TestChunk(t *testing.T) {
+	testCases := []struct {
+		name     string
+		n        int
+		size     int
+		expected [][]int
+	}{
+		{name: "Invalid Size", n: 3, size: 0, expected: nil},
+		{name: "Empty Sequence", n: 0, size: 2, expected: nil},
+		{name: "Exact Chunks", n: 4, size: 2, expected: [][]int{{0, 1}, {2, 3}}},
+		{name: "Partial Last Chunk", n: 5, size: 2, expected: [][]int{{0, 1}, {2, 3}, {4}}},
+		{name: "Single Chunk", n: 2, size: 5, expected: [][]int{{0, 1}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, slices.Collect(Chunk(numbers(tc.n), tc.size)))
+		})
+	}
+
+	t.Run("Infinite Sequence", func(t *testing.T) {
+		require.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}}, first(Chunk(naturals(), 3), 2))
+	})
+}
+
+func TestWindow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		n        int
+		size     int
+		expected [][]int
+	}{
+		{name: "Invalid Size", n: 3, size: 0, expected: nil},
+		{name: "Too Short Sequence", n: 2, size: 3, expected: nil},
+		{name: "Single Window", n: 3, size: 3, expected: [][]int{{0, 1, 2}}},
+		{name: "Sliding Windows", n: 5, size: 3, expected: [][]int{{0, 1, 2}, {1, 2, 3}, {2, 3, 4}}},
+		{name: "Windows Of One", n: 3, size: 1, expected: [][]int{{0}, {1}, {2}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, slices.Collect(Window(numbers(tc.n), tc.size)))
+		})
+	}
+
+	t.Run("Infinite Sequence", func(t *testing.T) {
+		require.Equal(t, [][]int{{0, 1}, {1, 2}, {2, 3}}, first(Window(naturals(), 2), 3))
+	})
+}
+
+func TestTakeWhile_DropWhile(t *testing.T) {
+	small := func(i int) bool { return i < 3 }
+
+	require.Equal(t, []int{0, 1, 2}, slices.Collect(TakeWhile(naturals(), small)))
+	require.Empty(t, slices.Collect(TakeWhile(numbers(0), small)))
+	require.Empty(t, slices.Collect(TakeWhile(slices.Values([]int{5, 1}), small)))
+
+	require.Equal(t, []int{3, 4}, slices.Collect(DropWhile(numbers(5), small)))
+	require.Equal(t, []int{5, 1}, slices.Collect(DropWhile(slices.Values([]int{0, 5, 1}), small)))
+	require.Empty(t, slices.Collect(DropWhile(numbers(2), small)))
+	require.Equal(t, []int{3, 4}, first(DropWhile(naturals(), small), 2))
+}
+
+func TestGroupBy(t *testing.T) {
+	require.Empty(t, GroupBy(numbers(0), func(i int) int { return i % 3 }))
+	require.Equal(t, map[int][]int{
+		0: {0, 3, 6},
+		1: {1, 4},
+		2: {2, 5},
+	}, GroupBy(numbers(7), func(i int) int { return i % 3 }))
+}
+
+func TestComposition(t *testing.T) {
+	// the sum of the squares of the odd numbers below 10
+	odd := Filter(numbers(10), func(i int) bool { return i%2 == 1 })
+	squares := Map(odd, func(i int) int { return i * i })
+
+	require.Equal(t, 1+9+25+49+81, Reduce(squares, 0, func(a, i int) int { return a + i }))
+}
@@ -0,0 +1,295 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
+// binomialNode represents a node in a binomial heap
+//
+// the children of a node are kept in a singly linked list starting at child, in decreasing
+// order of degree, and the roots of the heap are linked in increasing order of degree,
+// both through the sibling references
+type binomialNode[T any] struct {
+	value   T
+	degree  int
+	child   *binomialNode[T]
+	sibling *binomialNode[T]
+}
+
+// binomialHeap is an implementation of the MeldableHeap interface using a binomial heap
+type binomialHeap[T any] struct {
+	head       *binomialNode[T]
+	size       int
+	comparator func(t1, t2 T) bool
+}
+
+// link makes the child tree the first child of the parent tree, both trees should have the same degree
+//
+// O(1)
+func (h *binomialHeap[T]) link(child, parent *binomialNode[T]) {
+	child.sibling = parent.child
+	parent.child = child
+	parent.degree += 1
+}
+
+// mergeRootLists merges two root lists into one list sorted by degree
+//
+// O(log(n))
+func (h *binomialHeap[T]) mergeRootLists(a, b *binomialNode[T]) *binomialNode[T] {
+	var head binomialNode[T]
+
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling = a
+			a = a.sibling
+		} else {
+			tail.sibling = b
+			b = b.sibling
+		}
+		tail = tail.sibling
+	}
+
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+
+	return head.sibling
+}
+
+// union merges two root lists and links the trees of equal degree,
+// so that at most one tree of each degree remains
+//
+// O(log(n))
+func (h *binomialHeap[T]) union(a, b *binomialNode[T]) *binomialNode[T] {
+	head := h.mergeRootLists(a, b)
+	if head == nil {
+		return nil
+	}
+
+	var previous *binomialNode[T]
+	current := head
+	next := current.sibling
+	for next != nil {
+		if current.degree != next.degree || (next.sibling != nil && next.sibling.degree == current.degree) {
+			// nothing to link here, or the next two trees should be linked first
+			previous = current
+			current = next
+		} else if !h.comparator(next.value, current.value) {
+			// current keeps its place as the root
+			current.sibling = next.sibling
+			h.link(next, current)
+		} else {
+			// next becomes the root
+			if previous == nil {
+				head = next
+			} else {
+				previous.sibling = next
+			}
+			h.link(current, next)
+			current = next
+		}
+
+		next = current.sibling
+	}
+
+	return head
+}
+
+// top returns the root with the highest priority and the root before it in the root list
+//
+// O(log(n))
+func (h *binomialHeap[T]) top() (top, previous *binomialNode[T]) {
+	top = h.head
+
+	var prev *binomialNode[T]
+	for current := h.head; current != nil; prev, current = current, current.sibling {
+		if h.comparator(current.value, top.value) {
+			top = current
+			previous = prev
+		}
+	}
+
+	return top, previous
+}
+
+// Insert adds an element to the heap
+//
+// amortized O(1), worst case O(log(n))
+func (h *binomialHeap[T]) Insert(t T) {
+	h.head = h.union(h.head, &binomialNode[T]{value: t})
+	h.size += 1
+}
+
+// Extract removes and returns the root element from the heap
+//
+// returns ok = false if heap is empty
+// O(log(n))
+func (h *binomialHeap[T]) Extract() (t T, ok bool) {
+	if h.head == nil {
+		return
+	}
+
+	top, previous := h.top()
+
+	// remove the tree from the root list
+	if previous == nil {
+		h.head = top.sibling
+	} else {
+		previous.sibling = top.sibling
+	}
+
+	// the children are in decreasing order of degree, reverse them to form a root list
+	var children *binomialNode[T]
+	for child := top.child; child != nil; {
+		next := child.sibling
+		child.sibling = children
+		children = child
+		child = next
+	}
+
+	h.head = h.union(h.head, children)
+	h.size -= 1
+
+	// clearing references to help garbage collection
+	top.child = nil
+	top.sibling = nil
+
+	return top.value, true
+}
+
+// Peek returns the root element without removing it
+//
+// returns ok = false if heap is empty
+// O(log(n))
+func (h *binomialHeap[T]) Peek() (t T, ok bool) {
+	if h.head == nil {
+		return
+	}
+
+	top, _ := h.top()
+
+	return top.value, true
+}
+
+// Size returns the number of elements in the heap
+func (h *binomialHeap[T]) Size() int {
+	return h.size
+}
+
+// Meld moves all elements of the other heap into this heap, leaving the other heap empty
+//
+// returns ok = false if other is nil, is this same heap or is not a binomial heap
+// O(log(n))
+func (h *binomialHeap[T]) Meld(other MeldableHeap[T]) (ok bool) {
+	o, ok := other.(*binomialHeap[T])
+	if !ok || o == nil || o == h {
+		return false
+	}
+
+	h.head = h.union(h.head, o.head)
+	h.size += o.size
+
+	o.head = nil
+	o.size = 0
+
+	return true
+}
+
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *binomialHeap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, h.Size()))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// O(n)
+func (h *binomialHeap[T]) AppendTo(dst []T) []T {
+	return appendAll[T](dst, h)
+}
+
+// All returns an iterator over the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *binomialHeap[T]) All() iter.Seq[T] {
+	return h.forest().all()
+}
+
+// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+//
+// the heap should not be modified during the iteration
+// O(n*log(n)) to iterate all elements, computed lazily so stopping early costs less
+func (h *binomialHeap[T]) Sorted() iter.Seq[T] {
+	return h.forest().sorted(h.comparator)
+}
+
+// Drain returns an iterator that removes and yields the elements of the heap in priority order
+//
+// the elements not reached by a stopped iteration stay in the heap
+func (h *binomialHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// forest describes the trees of the heap to the iteration helpers
+func (h *binomialHeap[T]) forest() forest[*binomialNode[T], T] {
+	return forest[*binomialNode[T], T]{
+		roots: func(visit func(*binomialNode[T])) {
+			for root := h.head; root != nil; root = root.sibling {
+				visit(root)
+			}
+		},
+		children: func(node *binomialNode[T], visit func(*binomialNode[T])) {
+			for child := node.child; child != nil; child = child.sibling {
+				visit(child)
+			}
+		},
+		value: func(node *binomialNode[T]) T {
+			return node.value
+		},
+	}
+}
+
+// NewBinomialHeap creates a new binomial heap with the given comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap.
+//
+// Example usage:
+// - Min-Heap: NewBinomialHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
+func NewBinomialHeap[T any](less func(t1, t2 T) bool, data ...T) (h MeldableHeap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &binomialHeap[T]{
+		comparator: less,
+	}
+	for _, t := range data {
+		heap.Insert(t)
+	}
+
+	return heap
+}
+
+// NewMinBinomialHeap creates a new binomial min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinBinomialHeap(3, 1, 6, 5, 2, 4)
+func NewMinBinomialHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewBinomialHeap(comparator.Less[T], data...)
+}
+
+// NewMaxBinomialHeap creates a new binomial max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxBinomialHeap(3, 1, 6, 5, 2, 4)
+func NewMaxBinomialHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewBinomialHeap(comparator.Greater[T], data...)
+}
@@ -0,0 +1,77 @@
+package heap
+
+import "github.com/TheFeij/go-collections/internal/serial"
+
+// The binary heap is serialized as the sequence of its elements in the order of its underlying array.
+// Functions can not be serialized, so a heap is decoded into a heap created with its comparator:
+//
+//	h := NewHeap(comparator)
+//	err := json.Unmarshal(data, h)
+//
+// Decoding replaces the elements of the heap and rebuilds the heap property with the
+// comparator of the heap, so the data may also come from a heap with a different order.
+
+// MarshalJSON encodes the heap as a JSON array of its elements
+func (h *heap[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(h.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the heap with the elements of a JSON array
+//
+// the heap is left unchanged if data is invalid or is the JSON null
+// O(m)
+func (h *heap[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	h.replace(values)
+	return nil
+}
+
+// GobEncode encodes the heap as a gob stream of its elements
+func (h *heap[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(h.ToSlice())
+}
+
+// GobDecode replaces the elements of the heap with the elements of a gob stream written by GobEncode
+//
+// the heap is left unchanged if data is invalid
+// O(m)
+func (h *heap[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	h.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the heap in binary form, which is the same as GobEncode
+func (h *heap[T]) MarshalBinary() ([]byte, error) {
+	return h.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (h *heap[T]) UnmarshalBinary(data []byte) error {
+	return h.GobDecode(data)
+}
+
+// replace replaces the elements of the heap with the input values and builds the heap on them
+//
+// the handles of the current elements are invalidated, and the heap takes the ownership of the values slice
+// O(n + m)
+func (h *heap[T]) replace(values []T) {
+	for _, handle := range h.handles {
+		if handle != nil {
+			handle.heap = nil
+			handle.index = -1
+		}
+	}
+
+	h.handles = nil
+	h.data = values
+	h.buildHeap()
+}
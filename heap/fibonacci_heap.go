@@ -0,0 +1,492 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"github.com/TheFeij/go-collections/internal/owner"
+	"iter"
+)
+
+// FibonacciNode represents a node in a Fibonacci heap
+//
+// it is returned by InsertHandle as a handle to its element and stays valid
+// until the element leaves the heap through Extract or Delete
+type FibonacciNode[T any] struct {
+	value T
+
+	// parent and child link the node into its tree, the children of a node
+	// and the roots of the heap are kept in circular doubly linked lists
+	// through the left and right references
+	parent *FibonacciNode[T]
+	child  *FibonacciNode[T]
+	left   *FibonacciNode[T]
+	right  *FibonacciNode[T]
+
+	// degree is the number of children of the node
+	degree int
+
+	// marked reports whether the node has lost a child since it became the child of its parent
+	marked bool
+
+	// owner identifies the heap that the node belongs to, nil if the node has left the heap
+	owner *owner.Owner
+}
+
+// Value returns the element referenced by the node
+//
+// returns ok = false if the element is not in the heap anymore
+func (node *FibonacciNode[T]) Value() (t T, ok bool) {
+	if node.owner == nil {
+		return
+	}
+
+	return node.value, true
+}
+
+// fibonacciHeap is an implementation of the FibonacciHeap interface
+type fibonacciHeap[T any] struct {
+	min        *FibonacciNode[T]
+	size       int
+	comparator func(t1, t2 T) bool
+
+	// owner is the owner of the nodes of the heap, always a root owner
+	//
+	// when the heap is melded into another heap, its owner is linked to the owner of the
+	// other heap instead of updating every node
+	owner *owner.Owner
+
+	// roots and degrees are scratch buffers reused by consolidate to avoid allocations
+	roots   []*FibonacciNode[T]
+	degrees []*FibonacciNode[T]
+}
+
+// splice inserts the node into a circular list, right after the mark node
+func splice[T any](mark, node *FibonacciNode[T]) {
+	node.left = mark
+	node.right = mark.right
+	mark.right.left = node
+	mark.right = node
+}
+
+// concat joins two circular lists into one
+func concat[T any](a, b *FibonacciNode[T]) {
+	aRight := a.right
+	bLeft := b.left
+
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+// unlink removes the node from its circular list, leaving it in a list of its own
+func unlink[T any](node *FibonacciNode[T]) {
+	node.left.right = node.right
+	node.right.left = node.left
+	node.left = node
+	node.right = node
+}
+
+// addRoot adds a node that is in a list of its own to the root list, updating the minimum
+func (h *fibonacciHeap[T]) addRoot(node *FibonacciNode[T]) {
+	node.parent = nil
+
+	if h.min == nil {
+		node.left = node
+		node.right = node
+		h.min = node
+		return
+	}
+
+	splice(h.min, node)
+	if h.comparator(node.value, h.min.value) {
+		h.min = node
+	}
+}
+
+// link makes the child root a child of the parent root
+//
+// the root list is rebuilt by consolidate, so the child is not unlinked from it here
+func (h *fibonacciHeap[T]) link(child, parent *FibonacciNode[T]) {
+	child.parent = parent
+	child.marked = false
+
+	if parent.child == nil {
+		child.left = child
+		child.right = child
+		parent.child = child
+	} else {
+		splice(parent.child, child)
+	}
+
+	parent.degree += 1
+}
+
+// consolidate links the roots of equal degree until every root has a distinct degree,
+// then rebuilds the root list and finds the new minimum
+//
+// amortized O(log(n))
+func (h *fibonacciHeap[T]) consolidate() {
+	roots := h.roots[:0]
+	for node := h.min; ; {
+		roots = append(roots, node)
+		node = node.right
+		if node == h.min {
+			break
+		}
+	}
+
+	degrees := h.degrees[:0]
+	for _, x := range roots {
+		degree := x.degree
+		for degree < len(degrees) && degrees[degree] != nil {
+			y := degrees[degree]
+			if h.comparator(y.value, x.value) {
+				x, y = y, x
+			}
+
+			h.link(y, x)
+			degrees[degree] = nil
+			degree++
+		}
+
+		for len(degrees) <= degree {
+			degrees = append(degrees, nil)
+		}
+		degrees[degree] = x
+	}
+
+	h.min = nil
+	for i, node := range degrees {
+		if node != nil {
+			h.addRoot(node)
+		}
+
+		// clear the buffer to help garbage collection
+		degrees[i] = nil
+	}
+	clear(roots)
+
+	h.roots = roots
+	h.degrees = degrees
+}
+
+// removeRoot removes the input root from the heap, moving its children to the root list
+//
+// amortized O(log(n))
+func (h *fibonacciHeap[T]) removeRoot(node *FibonacciNode[T]) {
+	if node.child != nil {
+		child := node.child
+		for {
+			child.parent = nil
+			child = child.right
+			if child == node.child {
+				break
+			}
+		}
+
+		concat(node, node.child)
+		node.child = nil
+	}
+
+	if node.right == node {
+		h.min = nil
+	} else {
+		h.min = node.right
+		unlink(node)
+		h.consolidate()
+	}
+
+	h.size -= 1
+
+	// clearing references to help garbage collection
+	node.left = nil
+	node.right = nil
+	node.degree = 0
+	node.owner = nil
+}
+
+// cut moves the node from the children of its parent to the root list
+//
+// O(1)
+func (h *fibonacciHeap[T]) cut(node, parent *FibonacciNode[T]) {
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		unlink(node)
+	}
+
+	parent.degree -= 1
+	node.marked = false
+
+	h.addRoot(node)
+}
+
+// cascadingCut cuts the ancestors of a node that have already lost a child,
+// and marks the first ancestor that has not
+//
+// amortized O(1)
+func (h *fibonacciHeap[T]) cascadingCut(node *FibonacciNode[T]) {
+	for node.parent != nil {
+		if !node.marked {
+			node.marked = true
+			return
+		}
+
+		parent := node.parent
+		h.cut(node, parent)
+		node = parent
+	}
+}
+
+// owns reports whether the input node is an element of the heap
+func (h *fibonacciHeap[T]) owns(node *FibonacciNode[T]) bool {
+	return node != nil && node.owner != nil && node.owner.Find() == h.owner
+}
+
+// Insert adds an element to the heap
+//
+// O(1)
+func (h *fibonacciHeap[T]) Insert(t T) {
+	h.InsertHandle(t)
+}
+
+// InsertHandle adds an element to the heap and returns its node as a handle
+//
+// O(1)
+func (h *fibonacciHeap[T]) InsertHandle(t T) *FibonacciNode[T] {
+	node := &FibonacciNode[T]{
+		value: t,
+		owner: h.owner,
+	}
+
+	h.addRoot(node)
+	h.size += 1
+
+	return node
+}
+
+// Extract removes and returns the root element from the heap
+//
+// returns ok = false if heap is empty
+// amortized O(log(n))
+func (h *fibonacciHeap[T]) Extract() (t T, ok bool) {
+	if h.min == nil {
+		return
+	}
+
+	node := h.min
+	h.removeRoot(node)
+
+	return node.value, true
+}
+
+// Peek returns the root element without removing it
+//
+// returns ok = false if heap is empty
+func (h *fibonacciHeap[T]) Peek() (t T, ok bool) {
+	if h.min == nil {
+		return
+	}
+
+	return h.min.value, true
+}
+
+// Size returns the number of elements in the heap
+func (h *fibonacciHeap[T]) Size() int {
+	return h.size
+}
+
+// DecreaseKey replaces the element of the input node with an element of higher
+// or equal priority, according to the comparator
+//
+// returns ok = false if the node is not an element of the heap or the new
+// element has a lower priority than the current one
+// amortized O(1)
+func (h *fibonacciHeap[T]) DecreaseKey(node *FibonacciNode[T], t T) (ok bool) {
+	if !h.owns(node) || h.comparator(node.value, t) {
+		return
+	}
+
+	node.value = t
+
+	parent := node.parent
+	if parent != nil && h.comparator(node.value, parent.value) {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+
+	if h.comparator(node.value, h.min.value) {
+		h.min = node
+	}
+
+	return true
+}
+
+// Delete removes and returns the element of the input node
+//
+// returns ok = false if the node is not an element of the heap
+// amortized O(log(n))
+func (h *fibonacciHeap[T]) Delete(node *FibonacciNode[T]) (t T, ok bool) {
+	if !h.owns(node) {
+		return
+	}
+
+	if parent := node.parent; parent != nil {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+
+	h.removeRoot(node)
+
+	return node.value, true
+}
+
+// Meld moves all elements of the other heap into this heap, leaving the other heap empty
+//
+// returns ok = false if other is nil, is this same heap or is not a Fibonacci heap
+// O(1)
+func (h *fibonacciHeap[T]) Meld(other MeldableHeap[T]) (ok bool) {
+	o, ok := other.(*fibonacciHeap[T])
+	if !ok || o == nil || o == h {
+		return false
+	}
+
+	if o.min != nil {
+		if h.min == nil {
+			h.min = o.min
+		} else {
+			concat(h.min, o.min)
+			if h.comparator(o.min.value, h.min.value) {
+				h.min = o.min
+			}
+		}
+	}
+	h.size += o.size
+
+	// the nodes of the other heap now belong to this heap
+	o.owner.LinkTo(h.owner)
+	o.owner = &owner.Owner{}
+	o.min = nil
+	o.size = 0
+
+	return true
+}
+
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *fibonacciHeap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, h.Size()))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// O(n)
+func (h *fibonacciHeap[T]) AppendTo(dst []T) []T {
+	return appendAll[T](dst, h)
+}
+
+// All returns an iterator over the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *fibonacciHeap[T]) All() iter.Seq[T] {
+	return h.forest().all()
+}
+
+// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+//
+// the heap should not be modified during the iteration
+// O(n*log(n)) to iterate all elements, computed lazily so stopping early costs less
+func (h *fibonacciHeap[T]) Sorted() iter.Seq[T] {
+	return h.forest().sorted(h.comparator)
+}
+
+// Drain returns an iterator that removes and yields the elements of the heap in priority order
+//
+// the elements not reached by a stopped iteration stay in the heap
+func (h *fibonacciHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// forest describes the trees of the heap to the iteration helpers
+func (h *fibonacciHeap[T]) forest() forest[*FibonacciNode[T], T] {
+	return forest[*FibonacciNode[T], T]{
+		roots: func(visit func(*FibonacciNode[T])) {
+			if h.min == nil {
+				return
+			}
+
+			root := h.min
+			for {
+				visit(root)
+
+				root = root.right
+				if root == h.min {
+					return
+				}
+			}
+		},
+		children: func(node *FibonacciNode[T], visit func(*FibonacciNode[T])) {
+			if node.child == nil {
+				return
+			}
+
+			child := node.child
+			for {
+				visit(child)
+
+				child = child.right
+				if child == node.child {
+					return
+				}
+			}
+		},
+		value: func(node *FibonacciNode[T]) T {
+			return node.value
+		},
+	}
+}
+
+// NewFibonacciHeap creates a new Fibonacci heap with the given comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap.
+//
+// Example usage:
+// - Min-Heap: NewFibonacciHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
+func NewFibonacciHeap[T any](less func(t1, t2 T) bool, data ...T) (h FibonacciHeap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &fibonacciHeap[T]{
+		comparator: less,
+		owner:      &owner.Owner{},
+	}
+	for _, t := range data {
+		heap.Insert(t)
+	}
+
+	return heap
+}
+
+// NewMinFibonacciHeap creates a new Fibonacci min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinFibonacciHeap(3, 1, 6, 5, 2, 4)
+func NewMinFibonacciHeap[T cmp.Ordered](data ...T) FibonacciHeap[T] {
+	return NewFibonacciHeap(comparator.Less[T], data...)
+}
+
+// NewMaxFibonacciHeap creates a new Fibonacci max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxFibonacciHeap(3, 1, 6, 5, 2, 4)
+func NewMaxFibonacciHeap[T cmp.Ordered](data ...T) FibonacciHeap[T] {
+	return NewFibonacciHeap(comparator.Greater[T], data...)
+}
@@ -0,0 +1,115 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+)
+
+// FromSeq creates a new heap with the given comparator holding the elements of the input sequence
+//
+// the elements are collected first and the heap is built from them at once, as in NewHeap
+// # Returns nil if less is nil
+// O(n)
+func FromSeq[T any](less func(t1, t2 T) bool, seq iter.Seq[T]) HandleHeap[T] {
+	if less == nil {
+		return nil
+	}
+
+	h := &heap[T]{comparator: less}
+	for t := range seq {
+		h.data = append(h.data, t)
+	}
+
+	h.buildHeap()
+
+	return h
+}
+
+// From creates a new heap with the given comparator holding the input values
+//
+// it is the same as NewHeap, named for consistency with the From constructors of the other packages
+// # Returns nil if less is nil
+// O(n)
+func From[T any](less func(t1, t2 T) bool, values ...T) HandleHeap[T] {
+	return NewHeap(less, values...)
+}
+
+// FromSlice creates a new heap with the given comparator holding the elements of the input slice
+//
+// the slice is copied and not referenced by the heap
+// # Returns nil if less is nil
+// O(n)
+func FromSlice[T any](less func(t1, t2 T) bool, s []T) HandleHeap[T] {
+	return NewHeap(less, s...)
+}
+
+// MinMaxHeapFromSeq creates a new min-max heap with the given less-than comparator holding the elements of the input sequence
+//
+// the elements are collected first and the heap is built from them at once, as in NewMinMaxHeap
+// # Returns nil if less is nil
+// O(n)
+func MinMaxHeapFromSeq[T any](less func(t1, t2 T) bool, seq iter.Seq[T]) MinMaxHeap[T] {
+	if less == nil {
+		return nil
+	}
+
+	h := &minMaxHeap[T]{less: less}
+	for t := range seq {
+		h.data = append(h.data, t)
+	}
+
+	h.buildHeap()
+
+	return h
+}
+
+// MinMaxHeapFrom creates a new min-max heap with the given less-than comparator holding the input values
+//
+// it is the same as NewMinMaxHeap, named for consistency with From
+// # Returns nil if less is nil
+// O(n)
+func MinMaxHeapFrom[T any](less func(t1, t2 T) bool, values ...T) MinMaxHeap[T] {
+	return NewMinMaxHeap(less, values...)
+}
+
+// MinMaxHeapFromSlice creates a new min-max heap with the given less-than comparator holding the elements of the input slice
+//
+// the slice is copied and not referenced by the heap
+// # Returns nil if less is nil
+// O(n)
+func MinMaxHeapFromSlice[T any](less func(t1, t2 T) bool, s []T) MinMaxHeap[T] {
+	return NewMinMaxHeap(less, s...)
+}
+
+// TopKFromSeq creates a new top-k with the given comparator keeping the best k elements of the input sequence
+//
+// the first k elements are heapified at once and the rest are offered one by one
+// # Returns nil if less is nil or k is not positive
+// O(k + n log(k))
+func TopKFromSeq[T any](k int, less func(t1, t2 T) bool, seq iter.Seq[T]) TopK[T] {
+	if less == nil || k <= 0 {
+		return nil
+	}
+
+	tk := NewTopK(k, less).(*topK[T])
+	tk.fill(seq)
+
+	return tk
+}
+
+// TopKFrom creates a new top-k with the given comparator keeping the best k of the input values
+//
+// # Returns nil if less is nil or k is not positive
+// O(k + n log(k))
+func TopKFrom[T any](k int, less func(t1, t2 T) bool, values ...T) TopK[T] {
+	return TopKFromSeq(k, less, slices.Values(values))
+}
+
+// TopKFromSlice creates a new top-k with the given comparator keeping the best k elements of the input slice
+//
+// the kept elements are copied and the slice is not referenced by the top-k
+// # Returns nil if less is nil or k is not positive
+// O(k + n log(k))
+func TopKFromSlice[T any](k int, less func(t1, t2 T) bool, s []T) TopK[T] {
+	return TopKFromSeq(k, less, slices.Values(s))
+}
@@ -1,9 +1,62 @@
 package heap
 
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
 // heap represents a generic heap data structure
 type heap[T any] struct {
 	data       []T
 	comparator func(t1, t2 T) bool
+
+	// handles holds the handle of each element of data at the same index,
+	// nil for elements inserted without a handle
+	//
+	// it stays nil until the first handle is requested, so heaps
+	// that do not use handles pay nothing for them
+	handles []*Handle[T]
+}
+
+// Handle references an element inside a heap
+//
+// it is returned by InsertHandle and stays valid until the element
+// leaves the heap through Extract or Remove
+type Handle[T any] struct {
+	// index is the position of the element in the data of its heap
+	index int
+
+	// heap is the heap that the element belongs to, nil if the element has left the heap
+	heap *heap[T]
+}
+
+// Value returns the element referenced by the handle
+//
+// returns ok = false if the element is not in the heap anymore
+func (handle *Handle[T]) Value() (t T, ok bool) {
+	if handle.heap == nil {
+		return
+	}
+
+	return handle.heap.data[handle.index], true
+}
+
+// swap swaps the elements at the input indexes, keeping their handles up to date
+func (h *heap[T]) swap(i, j int) {
+	h.data[i], h.data[j] = h.data[j], h.data[i]
+
+	if h.handles == nil {
+		return
+	}
+
+	h.handles[i], h.handles[j] = h.handles[j], h.handles[i]
+	if h.handles[i] != nil {
+		h.handles[i].index = i
+	}
+	if h.handles[j] != nil {
+		h.handles[j].index = j
+	}
 }
 
 // heapifyDown performs heapify down operation from input root index
@@ -23,7 +76,7 @@ func (h *heap[T]) heapifyDown(root int) {
 	}
 
 	if largest != root {
-		h.data[root], h.data[largest] = h.data[largest], h.data[root]
+		h.swap(root, largest)
 
 		h.heapifyDown(largest)
 	}
@@ -45,19 +98,138 @@ func (h *heap[T]) heapifyUp(index int) {
 	parent := (index - 1) / 2
 
 	if parent >= 0 && h.comparator(h.data[index], h.data[parent]) {
-		h.data[parent], h.data[index] = h.data[index], h.data[parent]
+		h.swap(parent, index)
 
 		h.heapifyUp(parent)
 	}
 }
 
+// fix restores the heap property after the element at the input index has changed
+//
+// O(log(n))
+func (h *heap[T]) fix(index int) {
+	if index > 0 && h.comparator(h.data[index], h.data[(index-1)/2]) {
+		h.heapifyUp(index)
+	} else {
+		h.heapifyDown(index)
+	}
+}
+
 // Insert adds an element to the heap
 func (h *heap[T]) Insert(t T) {
 	h.data = append(h.data, t)
+	if h.handles != nil {
+		h.handles = append(h.handles, nil)
+	}
 
 	h.heapifyUp(len(h.data) - 1)
 }
 
+// InsertHandle adds an element to the heap and returns a handle to it
+//
+// O(log(n))
+func (h *heap[T]) InsertHandle(t T) *Handle[T] {
+	if h.handles == nil {
+		h.handles = make([]*Handle[T], len(h.data), cap(h.data))
+	}
+
+	handle := &Handle[T]{
+		index: len(h.data),
+		heap:  h,
+	}
+
+	h.data = append(h.data, t)
+	h.handles = append(h.handles, handle)
+
+	h.heapifyUp(handle.index)
+
+	return handle
+}
+
+// Update replaces the element referenced by the input handle and restores the heap property
+//
+// returns ok = false if the handle does not reference an element of the heap
+// O(log(n))
+func (h *heap[T]) Update(handle *Handle[T], t T) (ok bool) {
+	if !h.owns(handle) {
+		return
+	}
+
+	h.data[handle.index] = t
+	h.fix(handle.index)
+
+	return true
+}
+
+// Fix restores the heap property after the element referenced by the input handle
+// has been changed in place, for example through a pointer
+//
+// returns ok = false if the handle does not reference an element of the heap
+// O(log(n))
+func (h *heap[T]) Fix(handle *Handle[T]) (ok bool) {
+	if !h.owns(handle) {
+		return
+	}
+
+	h.fix(handle.index)
+
+	return true
+}
+
+// Remove removes and returns the element referenced by the input handle
+//
+// returns ok = false if the handle does not reference an element of the heap
+// O(log(n))
+func (h *heap[T]) Remove(handle *Handle[T]) (t T, ok bool) {
+	if !h.owns(handle) {
+		return
+	}
+
+	index := handle.index
+	lastIndex := len(h.data) - 1
+
+	// move the element to the end of the heap, so it can be cut off
+	h.swap(index, lastIndex)
+	t = h.removeLast()
+
+	if index != lastIndex {
+		h.fix(index)
+	}
+
+	return t, true
+}
+
+// owns reports whether the input handle references an element of the heap
+func (h *heap[T]) owns(handle *Handle[T]) bool {
+	return handle != nil && handle.heap == h
+}
+
+// removeLast removes and returns the last element of h.data, invalidating its handle
+//
+// should not be called on an empty heap
+func (h *heap[T]) removeLast() T {
+	var zero T
+
+	lastIndex := len(h.data) - 1
+	t := h.data[lastIndex]
+
+	// clear the reference to help garbage collection
+	h.data[lastIndex] = zero
+	h.data = h.data[:lastIndex]
+
+	if h.handles != nil {
+		if handle := h.handles[lastIndex]; handle != nil {
+			handle.heap = nil
+			handle.index = -1
+		}
+
+		h.handles[lastIndex] = nil
+		h.handles = h.handles[:lastIndex]
+	}
+
+	return t
+}
+
 // Extract removes and returns the root element from the heap
 //
 // returns ok = false if heap is empty
@@ -67,18 +239,10 @@ func (h *heap[T]) Extract() (t T, ok bool) {
 		return
 	}
 
-	if size == 1 {
-		t = h.data[0]
-		h.data = make([]T, 0)
+	// move the root to the end of the heap, so it can be cut off
+	h.swap(0, size-1)
+	t = h.removeLast()
 
-		return t, true
-	}
-
-	lastIndex := size - 1
-	t = h.data[0]
-	h.data[0] = h.data[lastIndex]
-
-	h.data = h.data[:lastIndex]
 	h.heapifyDown(0)
 
 	return t, true
@@ -100,25 +264,90 @@ func (h *heap[T]) Size() int {
 	return len(h.data)
 }
 
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *heap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, len(h.data)))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// the elements are appended in the order of the underlying array, the same order as All
+// O(n)
+func (h *heap[T]) AppendTo(dst []T) []T {
+	return append(dst, h.data...)
+}
+
+// All returns an iterator over the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, t := range h.data {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+//
+// the heap should not be modified during the iteration
+// O(k*log(k)) to iterate the first k elements
+func (h *heap[T]) Sorted() iter.Seq[T] {
+	return h.forest().sorted(h.comparator)
+}
+
+// Drain returns an iterator that removes and yields the elements of the heap in priority order
+//
+// the elements not reached by a stopped iteration stay in the heap
+func (h *heap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// forest describes the heap as a tree of indexes, where the children of index i are 2i+1 and 2i+2
+func (h *heap[T]) forest() forest[int, T] {
+	return forest[int, T]{
+		roots: func(visit func(int)) {
+			if len(h.data) > 0 {
+				visit(0)
+			}
+		},
+		children: func(index int, visit func(int)) {
+			if left := 2*index + 1; left < len(h.data) {
+				visit(left)
+			}
+			if right := 2*index + 2; right < len(h.data) {
+				visit(right)
+			}
+		},
+		value: func(index int) T {
+			return h.data[index]
+		},
+	}
+}
+
 // NewHeap creates a new heap with the given comparator and optional initial elements
 //
-// # Returns nil if comparator is nil
+// # Returns nil if less is nil
 //
-// The comparator function defines the heap property:
-// - For a max-heap, comparator should return true if the first argument is greater than the second
-// - For a min-heap, comparator should return true if the first argument is less than the second
+// The less function defines the heap property:
+// - For a max-heap, less should return true if the first argument is greater than the second
+// - For a min-heap, less should return true if the first argument is less than the second
 //
 // Example usage:
 // - Max-Heap: NewHeap(func(a, b int) bool { return a > b }, 3, 1, 6, 5, 2, 4)
 // - Min-Heap: NewHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
-func NewHeap[T any](comparator func(t1, t2 T) bool, data ...T) (h Heap[T]) {
-	if comparator == nil {
+func NewHeap[T any](less func(t1, t2 T) bool, data ...T) (h HandleHeap[T]) {
+	if less == nil {
 		return
 	}
 
 	heap := &heap[T]{
 		data:       make([]T, len(data)),
-		comparator: comparator,
+		comparator: less,
 	}
 	// copy input variadic to prevent storing reference to the input slice in the case where input is an unpacked slice
 	copy(heap.data, data)
@@ -127,3 +356,19 @@ func NewHeap[T any](comparator func(t1, t2 T) bool, data ...T) (h Heap[T]) {
 
 	return heap
 }
+
+// NewMinHeap creates a new min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinHeap(3, 1, 6, 5, 2, 4)
+func NewMinHeap[T cmp.Ordered](data ...T) HandleHeap[T] {
+	return NewHeap(comparator.Less[T], data...)
+}
+
+// NewMaxHeap creates a new max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxHeap(3, 1, 6, 5, 2, 4)
+func NewMaxHeap[T cmp.Ordered](data ...T) HandleHeap[T] {
+	return NewHeap(comparator.Greater[T], data...)
+}
@@ -1,296 +1,2139 @@
 package heap
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/TheFeij/go-collections/linkedlist"
 	"github.com/stretchr/testify/require"
+	"iter"
 	"math"
+	"math/rand"
+	"slices"
 	"sort"
 	"testing"
 )
 
 var data = []int{2, 4, 6, 7, -53, -1, 34, 68, 0, 0}
 
+// heaps is the list of heap implementations that the behavioral tests run against
+var heaps = []struct {
+	name    string
+	newHeap func(comparator func(t1, t2 int) bool, data ...int) Heap[int]
+}{
+	{
+		name: "binary heap",
+		newHeap: func(comparator func(t1, t2 int) bool, data ...int) Heap[int] {
+			return NewHeap(comparator, data...)
+		},
+	},
+	{
+		name: "pairing heap",
+		newHeap: func(comparator func(t1, t2 int) bool, data ...int) Heap[int] {
+			return NewPairingHeap(comparator, data...)
+		},
+	},
+	{
+		name: "binomial heap",
+		newHeap: func(comparator func(t1, t2 int) bool, data ...int) Heap[int] {
+			return NewBinomialHeap(comparator, data...)
+		},
+	},
+	{
+		name: "leftist heap",
+		newHeap: func(comparator func(t1, t2 int) bool, data ...int) Heap[int] {
+			return NewLeftistHeap(comparator, data...)
+		},
+	},
+	{
+		name: "skew heap",
+		newHeap: func(comparator func(t1, t2 int) bool, data ...int) Heap[int] {
+			return NewSkewHeap(comparator, data...)
+		},
+	},
+	{
+		name: "fibonacci heap",
+		newHeap: func(comparator func(t1, t2 int) bool, data ...int) Heap[int] {
+			return NewFibonacciHeap(comparator, data...)
+		},
+	},
+	{
+		name:    "stable heap",
+		newHeap: NewStableHeap[int],
+	},
+}
+
 func TestNewHeap(t *testing.T) {
-	t.Run("Heap Without Comparator", func(t *testing.T) {
-		h := NewHeap[int](nil)
+	for _, implementation := range heaps {
+		t.Run(implementation.name, func(t *testing.T) {
+			t.Run("Heap Without Comparator", func(t *testing.T) {
+				h := implementation.newHeap(nil)
 
-		require.Nil(t, h)
-	})
-	t.Run("Min Heap Without Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 < t2
+				require.Nil(t, h)
+			})
+			t.Run("Min Heap Without Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 < t2
+				})
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 0, h.Size())
+
+				value, ok := h.Peek()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				value, ok = h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				require.Equal(t, 0, h.Size())
+			})
+			t.Run("Min Heap With Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 < t2
+				}, data...)
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, len(data), h.Size())
+
+				sort.Ints(data)
+
+				for _, number := range data {
+					value, ok := h.Peek()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+
+					value, ok = h.Extract()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+				}
+
+				value, ok := h.Peek()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				value, ok = h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				require.Equal(t, 0, h.Size())
+			})
+			t.Run("Max Heap Without Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 > t2
+				})
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 0, h.Size())
+
+				value, ok := h.Peek()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				value, ok = h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				require.Equal(t, 0, h.Size())
+			})
+			t.Run("Min Heap With Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 > t2
+				}, data...)
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, len(data), h.Size())
+
+				sort.Slice(data, func(i, j int) bool {
+					return data[i] > data[j]
+				})
+
+				for _, number := range data {
+					value, ok := h.Peek()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+
+					value, ok = h.Extract()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+				}
+
+				value, ok := h.Peek()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				value, ok = h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				require.Equal(t, 0, h.Size())
+			})
 		})
+	}
+}
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 0, h.Size())
+func TestHeap_Insert(t *testing.T) {
+	for _, implementation := range heaps {
+		t.Run(implementation.name, func(t *testing.T) {
+			t.Run("Insert To Min Heap Without Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 < t2
+				})
 
-		value, ok := h.Peek()
-		require.False(t, ok)
-		require.Zero(t, value)
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 0, h.Size())
 
-		value, ok = h.Extract()
-		require.False(t, ok)
-		require.Zero(t, value)
+				value, ok := h.Peek()
+				require.False(t, ok)
+				require.Zero(t, value)
 
-		require.Equal(t, 0, h.Size())
-	})
-	t.Run("Min Heap With Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 < t2
-		}, data...)
+				sort.Slice(data, func(i, j int) bool {
+					return data[i] > data[j]
+				})
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, len(data), h.Size())
+				for _, number := range data {
+					h.Insert(number)
 
-		sort.Ints(data)
+					value, ok := h.Peek()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+				}
+			})
+			t.Run("Insert To Min Heap With Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 < t2
+				}, math.MaxInt, math.MaxInt)
 
-		for _, number := range data {
-			value, ok := h.Peek()
-			require.True(t, ok)
-			require.Equal(t, number, value)
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 2, h.Size())
 
-			value, ok = h.Extract()
-			require.True(t, ok)
-			require.Equal(t, number, value)
-		}
+				value, ok := h.Peek()
+				require.True(t, ok)
+				require.Equal(t, math.MaxInt, value)
 
-		value, ok := h.Peek()
-		require.False(t, ok)
-		require.Zero(t, value)
+				sort.Slice(data, func(i, j int) bool {
+					return data[i] > data[j]
+				})
 
-		value, ok = h.Extract()
-		require.False(t, ok)
-		require.Zero(t, value)
+				for index, number := range data {
+					h.Insert(number)
 
-		require.Equal(t, 0, h.Size())
-	})
-	t.Run("Max Heap Without Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 > t2
+					value, ok := h.Peek()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+					require.Equal(t, index+3, h.Size())
+				}
+			})
+			t.Run("Insert To Max Heap Without Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 > t2
+				})
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 0, h.Size())
+
+				value, ok := h.Peek()
+				require.False(t, ok)
+				require.Zero(t, value)
+
+				sort.Slice(data, func(i, j int) bool {
+					return data[i] < data[j]
+				})
+
+				for _, number := range data {
+					h.Insert(number)
+
+					value, ok := h.Peek()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+				}
+			})
+			t.Run("Insert To Min Heap With Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 > t2
+				}, math.MinInt, math.MinInt)
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 2, h.Size())
+
+				value, ok := h.Peek()
+				require.True(t, ok)
+				require.Equal(t, math.MinInt, value)
+
+				sort.Slice(data, func(i, j int) bool {
+					return data[i] < data[j]
+				})
+
+				for index, number := range data {
+					h.Insert(number)
+
+					value, ok := h.Peek()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+					require.Equal(t, index+3, h.Size())
+				}
+			})
 		})
+	}
+}
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 0, h.Size())
+func TestHeap_Extract(t *testing.T) {
+	for _, implementation := range heaps {
+		t.Run(implementation.name, func(t *testing.T) {
+			t.Run("Extract From Min Heap Without Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 < t2
+				})
 
-		value, ok := h.Peek()
-		require.False(t, ok)
-		require.Zero(t, value)
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 0, h.Size())
 
-		value, ok = h.Extract()
-		require.False(t, ok)
-		require.Zero(t, value)
+				value, ok := h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+			})
+			t.Run("Extract From Min Heap With Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 < t2
+				}, math.MaxInt, math.MaxInt)
 
-		require.Equal(t, 0, h.Size())
-	})
-	t.Run("Min Heap With Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 > t2
-		}, data...)
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 2, h.Size())
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, len(data), h.Size())
+				value, ok := h.Extract()
+				require.True(t, ok)
+				require.Equal(t, math.MaxInt, value)
+
+				value, ok = h.Extract()
+				require.True(t, ok)
+				require.Equal(t, math.MaxInt, value)
+
+				value, ok = h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+			})
+			t.Run("Extract From Max Heap Without Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 > t2
+				})
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 0, h.Size())
+
+				value, ok := h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+			})
+			t.Run("Extract From Min Heap With Initial Values", func(t *testing.T) {
+				h := implementation.newHeap(func(t1, t2 int) bool {
+					return t1 > t2
+				}, math.MinInt, math.MinInt)
+
+				require.NotNil(t, h)
+				require.NotEmpty(t, h)
+				require.Equal(t, 2, h.Size())
 
-		sort.Slice(data, func(i, j int) bool {
-			return data[i] > data[j]
+				value, ok := h.Extract()
+				require.True(t, ok)
+				require.Equal(t, math.MinInt, value)
+
+				value, ok = h.Extract()
+				require.True(t, ok)
+				require.Equal(t, math.MinInt, value)
+
+				value, ok = h.Extract()
+				require.False(t, ok)
+				require.Zero(t, value)
+			})
 		})
+	}
+}
 
-		for _, number := range data {
-			value, ok := h.Peek()
-			require.True(t, ok)
-			require.Equal(t, number, value)
+func TestNewMinHeap_NewMaxHeap(t *testing.T) {
+	t.Run("Min Heap", func(t *testing.T) {
+		h := NewMinHeap(3, 1, 6, 5, 2, 4)
+		h.Insert(0)
 
-			value, ok = h.Extract()
+		for i := 0; i <= 6; i++ {
+			value, ok := h.Extract()
 			require.True(t, ok)
-			require.Equal(t, number, value)
+			require.Equal(t, i, value)
 		}
+		require.Equal(t, 0, h.Size())
+	})
+	t.Run("Max Heap", func(t *testing.T) {
+		h := NewMaxHeap("b", "d", "a", "c")
+		h.Insert("e")
 
-		value, ok := h.Peek()
-		require.False(t, ok)
-		require.Zero(t, value)
-
-		value, ok = h.Extract()
-		require.False(t, ok)
-		require.Zero(t, value)
-
+		for _, expected := range []string{"e", "d", "c", "b", "a"} {
+			value, ok := h.Extract()
+			require.True(t, ok)
+			require.Equal(t, expected, value)
+		}
 		require.Equal(t, 0, h.Size())
 	})
+	t.Run("Empty Heaps", func(t *testing.T) {
+		require.Equal(t, 0, NewMinHeap[float64]().Size())
+		require.Equal(t, 0, NewMaxHeap[float64]().Size())
+	})
 }
 
-func TestHeap_Insert(t *testing.T) {
-	t.Run("Insert To Min Heap Without Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 < t2
+func TestOrderedConstructors(t *testing.T) {
+	ascending := []int{0, 1, 2, 3, 4, 5, 6}
+	descending := []int{6, 5, 4, 3, 2, 1, 0}
+
+	ordered := []struct {
+		name     string
+		h        Heap[int]
+		expected []int
+	}{
+		{name: "Min Stable Heap", h: NewMinStableHeap(3, 1, 6, 5, 2, 4, 0), expected: ascending},
+		{name: "Max Stable Heap", h: NewMaxStableHeap(3, 1, 6, 5, 2, 4, 0), expected: descending},
+		{name: "Min Pairing Heap", h: NewMinPairingHeap(3, 1, 6, 5, 2, 4, 0), expected: ascending},
+		{name: "Max Pairing Heap", h: NewMaxPairingHeap(3, 1, 6, 5, 2, 4, 0), expected: descending},
+		{name: "Min Binomial Heap", h: NewMinBinomialHeap(3, 1, 6, 5, 2, 4, 0), expected: ascending},
+		{name: "Max Binomial Heap", h: NewMaxBinomialHeap(3, 1, 6, 5, 2, 4, 0), expected: descending},
+		{name: "Min Leftist Heap", h: NewMinLeftistHeap(3, 1, 6, 5, 2, 4, 0), expected: ascending},
+		{name: "Max Leftist Heap", h: NewMaxLeftistHeap(3, 1, 6, 5, 2, 4, 0), expected: descending},
+		{name: "Min Skew Heap", h: NewMinSkewHeap(3, 1, 6, 5, 2, 4, 0), expected: ascending},
+		{name: "Max Skew Heap", h: NewMaxSkewHeap(3, 1, 6, 5, 2, 4, 0), expected: descending},
+		{name: "Min Fibonacci Heap", h: NewMinFibonacciHeap(3, 1, 6, 5, 2, 4, 0), expected: ascending},
+		{name: "Max Fibonacci Heap", h: NewMaxFibonacciHeap(3, 1, 6, 5, 2, 4, 0), expected: descending},
+	}
+	for _, heap := range ordered {
+		t.Run(heap.name, func(t *testing.T) {
+			require.Equal(t, heap.expected, slices.Collect(heap.h.Drain()))
 		})
+	}
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 0, h.Size())
+	t.Run("Indexed Heaps", func(t *testing.T) {
+		minimum := NewMinIndexedHeap[string, int]()
+		maximum := NewMaxIndexedHeap[string, int]()
+		for key, value := range map[string]int{"a": 2, "b": 1, "c": 3} {
+			minimum.Push(key, value)
+			maximum.Push(key, value)
+		}
 
-		value, ok := h.Peek()
-		require.False(t, ok)
-		require.Zero(t, value)
+		key, value, ok := minimum.Pop()
+		require.True(t, ok)
+		require.Equal(t, "b", key)
+		require.Equal(t, 1, value)
 
-		sort.Slice(data, func(i, j int) bool {
-			return data[i] > data[j]
-		})
+		key, value, ok = maximum.Pop()
+		require.True(t, ok)
+		require.Equal(t, "c", key)
+		require.Equal(t, 3, value)
+	})
+	t.Run("Min-Max Heap", func(t *testing.T) {
+		h := NewOrderedMinMaxHeap("b", "d", "a", "c")
 
-		for _, number := range data {
-			h.Insert(number)
+		value, ok := h.PeekMin()
+		require.True(t, ok)
+		require.Equal(t, "a", value)
 
-			value, ok := h.Peek()
-			require.True(t, ok)
-			require.Equal(t, number, value)
+		value, ok = h.PeekMax()
+		require.True(t, ok)
+		require.Equal(t, "d", value)
+	})
+	t.Run("Top-K", func(t *testing.T) {
+		largest := NewTopKLargest[int](3)
+		smallest := NewTopKSmallest[int](3)
+		for _, value := range []int{3, 1, 6, 5, 2, 4, 0} {
+			largest.Offer(value)
+			smallest.Offer(value)
 		}
+
+		require.Equal(t, []int{6, 5, 4}, largest.Sorted())
+		require.Equal(t, []int{0, 1, 2}, smallest.Sorted())
+		require.Nil(t, NewTopKLargest[int](0))
+		require.Nil(t, NewTopKSmallest[int](-1))
 	})
-	t.Run("Insert To Min Heap With Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 < t2
-		}, math.MaxInt, math.MaxInt)
+}
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
+func TestHeap_Handle(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	t.Run("InsertHandle", func(t *testing.T) {
+		h := NewHeap[int](less, 5, 7)
+
+		handle := h.InsertHandle(3)
+		value, ok := handle.Value()
+		require.True(t, ok)
+		require.Equal(t, 3, value)
+		require.Equal(t, 3, h.Size())
+
+		value, ok = h.Peek()
+		require.True(t, ok)
+		require.Equal(t, 3, value)
+
+		value, ok = h.Extract()
+		require.True(t, ok)
+		require.Equal(t, 3, value)
+
+		// the handle is invalid once its element leaves the heap
+		_, ok = handle.Value()
+		require.False(t, ok)
+		require.False(t, h.Update(handle, 0))
+		require.False(t, h.Fix(handle))
+		_, ok = h.Remove(handle)
+		require.False(t, ok)
 		require.Equal(t, 2, h.Size())
+	})
+	t.Run("Update", func(t *testing.T) {
+		h := NewHeap[int](less)
 
+		handles := make([]*Handle[int], 10)
+		for i := range handles {
+			handles[i] = h.InsertHandle(i * 10)
+		}
+
+		// decrease key
+		require.True(t, h.Update(handles[9], -1))
 		value, ok := h.Peek()
 		require.True(t, ok)
-		require.Equal(t, math.MaxInt, value)
-
-		sort.Slice(data, func(i, j int) bool {
-			return data[i] > data[j]
-		})
+		require.Equal(t, -1, value)
 
-		for index, number := range data {
-			h.Insert(number)
+		// increase key
+		require.True(t, h.Update(handles[9], 1000))
+		require.True(t, h.Update(handles[0], 55))
 
-			value, ok := h.Peek()
+		expected := []int{10, 20, 30, 40, 50, 55, 60, 70, 80, 1000}
+		for _, number := range expected {
+			value, ok := h.Extract()
 			require.True(t, ok)
 			require.Equal(t, number, value)
-			require.Equal(t, index+3, h.Size())
 		}
 	})
-	t.Run("Insert To Max Heap Without Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 > t2
+	t.Run("Fix", func(t *testing.T) {
+		h := NewHeap[*int](func(t1, t2 *int) bool {
+			return *t1 < *t2
 		})
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 0, h.Size())
+		values := make([]int, 10)
+		handles := make([]*Handle[*int], 10)
+		for i := range values {
+			values[i] = i
+			handles[i] = h.InsertHandle(&values[i])
+		}
 
-		value, ok := h.Peek()
-		require.False(t, ok)
-		require.Zero(t, value)
+		values[0] = 100
+		require.True(t, h.Fix(handles[0]))
+		values[7] = -7
+		require.True(t, h.Fix(handles[7]))
 
-		sort.Slice(data, func(i, j int) bool {
-			return data[i] < data[j]
-		})
+		expected := []int{-7, 1, 2, 3, 4, 5, 6, 8, 9, 100}
+		for _, number := range expected {
+			value, ok := h.Extract()
+			require.True(t, ok)
+			require.Equal(t, number, *value)
+		}
+	})
+	t.Run("Remove", func(t *testing.T) {
+		h := NewHeap[int](less, 100, 200)
 
-		for _, number := range data {
-			h.Insert(number)
+		handles := make([]*Handle[int], 10)
+		for i := range handles {
+			handles[i] = h.InsertHandle(i)
+		}
 
-			value, ok := h.Peek()
+		for i := 0; i < 10; i += 3 {
+			value, ok := h.Remove(handles[i])
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+		require.Equal(t, 8, h.Size())
+
+		_, ok := h.Remove(handles[0])
+		require.False(t, ok)
+
+		expected := []int{1, 2, 4, 5, 7, 8, 100, 200}
+		for _, number := range expected {
+			value, ok := h.Extract()
 			require.True(t, ok)
 			require.Equal(t, number, value)
 		}
 	})
-	t.Run("Insert To Min Heap With Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 > t2
-		}, math.MinInt, math.MinInt)
+	t.Run("Handle Of Another Heap", func(t *testing.T) {
+		h := NewHeap[int](less)
+		other := NewHeap[int](less)
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 2, h.Size())
+		handle := other.InsertHandle(1)
+		require.False(t, h.Update(handle, 2))
+		require.False(t, h.Fix(handle))
+		_, ok := h.Remove(handle)
+		require.False(t, ok)
+		require.False(t, h.Update(nil, 2))
 
-		value, ok := h.Peek()
+		value, ok := handle.Value()
 		require.True(t, ok)
-		require.Equal(t, math.MinInt, value)
-
-		sort.Slice(data, func(i, j int) bool {
-			return data[i] < data[j]
-		})
+		require.Equal(t, 1, value)
+	})
+	t.Run("Randomized", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		h := NewHeap[int](less)
 
-		for index, number := range data {
-			h.Insert(number)
+		// the expected content of the heap is tracked in a map from handle to value
+		expected := make(map[*Handle[int]]int)
+		for i := 0; i < 10000; i++ {
+			switch operation := random.Intn(5); {
+			case operation == 0 || len(expected) == 0:
+				value := random.Intn(1000)
+				expected[h.InsertHandle(value)] = value
+			case operation == 1:
+				h.Insert(random.Intn(1000))
+			case operation == 2:
+				for handle := range expected {
+					value := random.Intn(1000)
+					require.True(t, h.Update(handle, value))
+					expected[handle] = value
+					break
+				}
+			case operation == 3:
+				for handle, value := range expected {
+					removed, ok := h.Remove(handle)
+					require.True(t, ok)
+					require.Equal(t, value, removed)
+					delete(expected, handle)
+					break
+				}
+			default:
+				minimum, ok := h.Peek()
+				require.True(t, ok)
+				for handle, value := range expected {
+					require.LessOrEqual(t, minimum, value)
+					current, ok := handle.Value()
+					require.True(t, ok)
+					require.Equal(t, value, current)
+				}
+			}
+		}
 
-			value, ok := h.Peek()
+		extracted := make([]int, 0, h.Size())
+		for h.Size() > 0 {
+			value, ok := h.Extract()
 			require.True(t, ok)
-			require.Equal(t, number, value)
-			require.Equal(t, index+3, h.Size())
+			extracted = append(extracted, value)
 		}
+		require.True(t, slices.IsSorted(extracted))
 	})
 }
 
-func TestHeap_Extract(t *testing.T) {
-	t.Run("Extract From Min Heap Without Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 < t2
+func TestNewIndexedHeap(t *testing.T) {
+	t.Run("Indexed Heap Without Comparator", func(t *testing.T) {
+		h := NewIndexedHeap[string, int](nil)
+
+		require.Nil(t, h)
+	})
+	t.Run("Empty Indexed Heap", func(t *testing.T) {
+		h := NewIndexedHeap[string, int](func(v1, v2 int) bool {
+			return v1 < v2
 		})
 
 		require.NotNil(t, h)
-		require.NotEmpty(t, h)
 		require.Equal(t, 0, h.Size())
 
-		value, ok := h.Extract()
+		key, value, ok := h.Peek()
 		require.False(t, ok)
+		require.Zero(t, key)
+		require.Zero(t, value)
+
+		key, value, ok = h.Pop()
+		require.False(t, ok)
+		require.Zero(t, key)
 		require.Zero(t, value)
 	})
-	t.Run("Extract From Min Heap With Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 < t2
-		}, math.MaxInt, math.MaxInt)
+}
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 2, h.Size())
+func TestIndexedHeap(t *testing.T) {
+	newHeap := func() IndexedHeap[string, int] {
+		h := NewIndexedHeap[string, int](func(v1, v2 int) bool {
+			return v1 < v2
+		})
 
-		value, ok := h.Extract()
-		require.True(t, ok)
-		require.Equal(t, math.MaxInt, value)
+		require.True(t, h.Push("c", 30))
+		require.True(t, h.Push("a", 10))
+		require.True(t, h.Push("d", 40))
+		require.True(t, h.Push("b", 20))
 
-		value, ok = h.Extract()
+		return h
+	}
+
+	t.Run("Push", func(t *testing.T) {
+		h := newHeap()
+		require.Equal(t, 4, h.Size())
+
+		require.False(t, h.Push("a", 0))
+		require.Equal(t, 4, h.Size())
+
+		value, ok := h.Get("a")
 		require.True(t, ok)
-		require.Equal(t, math.MaxInt, value)
+		require.Equal(t, 10, value)
 
-		value, ok = h.Extract()
-		require.False(t, ok)
-		require.Zero(t, value)
+		key, value, ok := h.Peek()
+		require.True(t, ok)
+		require.Equal(t, "a", key)
+		require.Equal(t, 10, value)
 	})
-	t.Run("Extract From Max Heap Without Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 > t2
-		})
+	t.Run("Pop", func(t *testing.T) {
+		h := newHeap()
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 0, h.Size())
+		for _, expected := range []string{"a", "b", "c", "d"} {
+			key, _, ok := h.Pop()
+			require.True(t, ok)
+			require.Equal(t, expected, key)
+			require.False(t, h.Contains(key))
+		}
 
-		value, ok := h.Extract()
+		_, _, ok := h.Pop()
 		require.False(t, ok)
-		require.Zero(t, value)
+
+		// popped keys can be pushed again
+		require.True(t, h.Push("a", 1))
+		require.True(t, h.Contains("a"))
 	})
-	t.Run("Extract From Min Heap With Initial Values", func(t *testing.T) {
-		h := NewHeap[int](func(t1, t2 int) bool {
-			return t1 > t2
-		}, math.MinInt, math.MinInt)
+	t.Run("Update", func(t *testing.T) {
+		h := newHeap()
 
-		require.NotNil(t, h)
-		require.NotEmpty(t, h)
-		require.Equal(t, 2, h.Size())
+		require.True(t, h.Update("d", 0))
+		require.True(t, h.Update("a", 50))
+		require.False(t, h.Update("e", 0))
 
-		value, ok := h.Extract()
+		value, ok := h.Get("d")
 		require.True(t, ok)
-		require.Equal(t, math.MinInt, value)
+		require.Equal(t, 0, value)
 
-		value, ok = h.Extract()
+		for _, expected := range []string{"d", "b", "c", "a"} {
+			key, _, ok := h.Pop()
+			require.True(t, ok)
+			require.Equal(t, expected, key)
+		}
+	})
+	t.Run("Delete", func(t *testing.T) {
+		h := newHeap()
+
+		value, ok := h.Delete("b")
 		require.True(t, ok)
-		require.Equal(t, math.MinInt, value)
+		require.Equal(t, 20, value)
+		require.False(t, h.Contains("b"))
+		require.Equal(t, 3, h.Size())
 
-		value, ok = h.Extract()
+		_, ok = h.Delete("b")
 		require.False(t, ok)
-		require.Zero(t, value)
+
+		_, ok = h.Get("b")
+		require.False(t, ok)
+
+		for _, expected := range []string{"a", "c", "d"} {
+			key, _, ok := h.Pop()
+			require.True(t, ok)
+			require.Equal(t, expected, key)
+		}
 	})
 }
+
+func TestHeap_Randomized(t *testing.T) {
+	for _, implementation := range heaps {
+		t.Run(implementation.name, func(t *testing.T) {
+			random := rand.New(rand.NewSource(1))
+
+			initial := make([]int, 100)
+			for i := range initial {
+				initial[i] = random.Intn(100)
+			}
+
+			h := implementation.newHeap(func(t1, t2 int) bool {
+				return t1 < t2
+			}, initial...)
+
+			// the expected content of the heap is kept sorted
+			expected := slices.Clone(initial)
+			slices.Sort(expected)
+
+			for i := 0; i < 10000; i++ {
+				if random.Intn(3) > 0 || len(expected) == 0 {
+					value := random.Intn(100)
+					h.Insert(value)
+
+					index, _ := slices.BinarySearch(expected, value)
+					expected = slices.Insert(expected, index, value)
+				} else {
+					value, ok := h.Extract()
+					require.True(t, ok)
+					require.Equal(t, expected[0], value)
+
+					expected = expected[1:]
+				}
+
+				require.Equal(t, len(expected), h.Size())
+			}
+
+			for _, number := range expected {
+				value, ok := h.Extract()
+				require.True(t, ok)
+				require.Equal(t, number, value)
+			}
+			require.Equal(t, 0, h.Size())
+		})
+	}
+}
+
+func TestHeap_Iterators(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	for _, implementation := range heaps {
+		t.Run(implementation.name, func(t *testing.T) {
+			// newShuffledHeap returns a heap whose internal structure has been changed
+			// by extracts, along with its elements in priority order
+			newShuffledHeap := func() (Heap[int], []int) {
+				random := rand.New(rand.NewSource(1))
+
+				h := implementation.newHeap(less, data...)
+				for i := 0; i < 200; i++ {
+					h.Insert(random.Intn(100))
+				}
+				for i := 0; i < 50; i++ {
+					h.Extract()
+				}
+
+				var expected []int
+				for t := range h.Sorted() {
+					expected = append(expected, t)
+				}
+				require.True(t, slices.IsSorted(expected))
+
+				return h, expected
+			}
+
+			t.Run("Empty Heap", func(t *testing.T) {
+				h := implementation.newHeap(less)
+
+				for range h.All() {
+					require.Fail(t, "All yielded an element of an empty heap")
+				}
+				for range h.Sorted() {
+					require.Fail(t, "Sorted yielded an element of an empty heap")
+				}
+				for range h.Drain() {
+					require.Fail(t, "Drain yielded an element of an empty heap")
+				}
+			})
+			t.Run("All", func(t *testing.T) {
+				h, expected := newShuffledHeap()
+
+				var values []int
+				for t := range h.All() {
+					values = append(values, t)
+				}
+
+				slices.Sort(values)
+				require.Equal(t, expected, values)
+				require.Equal(t, len(expected), h.Size())
+			})
+			t.Run("Sorted", func(t *testing.T) {
+				h, expected := newShuffledHeap()
+				require.Len(t, expected, len(data)+150)
+
+				// sorted does not change the heap
+				for _, number := range expected {
+					value, ok := h.Extract()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+				}
+				require.Equal(t, 0, h.Size())
+			})
+			t.Run("Sorted Break", func(t *testing.T) {
+				h, expected := newShuffledHeap()
+
+				var values []int
+				for t := range h.Sorted() {
+					if len(values) == 10 {
+						break
+					}
+					values = append(values, t)
+				}
+
+				require.Equal(t, expected[:10], values)
+				require.Equal(t, len(expected), h.Size())
+			})
+			t.Run("Drain", func(t *testing.T) {
+				h, expected := newShuffledHeap()
+
+				var values []int
+				for t := range h.Drain() {
+					values = append(values, t)
+				}
+
+				require.Equal(t, expected, values)
+				require.Equal(t, 0, h.Size())
+			})
+			t.Run("Drain Break", func(t *testing.T) {
+				h, expected := newShuffledHeap()
+
+				var values []int
+				for t := range h.Drain() {
+					values = append(values, t)
+					if len(values) == 10 {
+						break
+					}
+				}
+
+				require.Equal(t, expected[:10], values)
+				require.Equal(t, len(expected)-10, h.Size())
+
+				value, ok := h.Peek()
+				require.True(t, ok)
+				require.Equal(t, expected[10], value)
+			})
+		})
+	}
+}
+
+func TestMeldableHeap_Meld(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	meldableHeaps := []struct {
+		name    string
+		newHeap func(comparator func(t1, t2 int) bool, data ...int) MeldableHeap[int]
+	}{
+		{name: "pairing heap", newHeap: NewPairingHeap[int]},
+		{name: "binomial heap", newHeap: NewBinomialHeap[int]},
+		{name: "leftist heap", newHeap: NewLeftistHeap[int]},
+		{name: "skew heap", newHeap: NewSkewHeap[int]},
+		{
+			name: "fibonacci heap",
+			newHeap: func(comparator func(t1, t2 int) bool, data ...int) MeldableHeap[int] {
+				return NewFibonacciHeap(comparator, data...)
+			},
+		},
+	}
+
+	for _, implementation := range meldableHeaps {
+		t.Run(implementation.name, func(t *testing.T) {
+			t.Run("OK", func(t *testing.T) {
+				h := implementation.newHeap(less, 1, 3, 5, 7, 9)
+				other := implementation.newHeap(less, 0, 2, 4, 6, 8, 10)
+
+				require.True(t, h.Meld(other))
+				require.Equal(t, 11, h.Size())
+				require.Equal(t, 0, other.Size())
+
+				_, ok := other.Peek()
+				require.False(t, ok)
+
+				for i := 0; i <= 10; i++ {
+					value, ok := h.Extract()
+					require.True(t, ok)
+					require.Equal(t, i, value)
+				}
+
+				// the other heap can still be used after being melded
+				other.Insert(1)
+				value, ok := other.Peek()
+				require.True(t, ok)
+				require.Equal(t, 1, value)
+			})
+			t.Run("Empty Heaps", func(t *testing.T) {
+				h := implementation.newHeap(less)
+				other := implementation.newHeap(less, 2, 1)
+
+				require.True(t, h.Meld(other))
+				require.Equal(t, 2, h.Size())
+
+				require.True(t, h.Meld(implementation.newHeap(less)))
+				require.Equal(t, 2, h.Size())
+
+				value, ok := h.Extract()
+				require.True(t, ok)
+				require.Equal(t, 1, value)
+			})
+			t.Run("Many Heaps", func(t *testing.T) {
+				random := rand.New(rand.NewSource(1))
+				h := implementation.newHeap(less)
+
+				var expected []int
+				for i := 0; i < 100; i++ {
+					other := implementation.newHeap(less)
+					for j := random.Intn(50); j > 0; j-- {
+						value := random.Intn(1000)
+						other.Insert(value)
+						expected = append(expected, value)
+					}
+
+					require.True(t, h.Meld(other))
+					require.Equal(t, len(expected), h.Size())
+				}
+
+				slices.Sort(expected)
+				for _, number := range expected {
+					value, ok := h.Extract()
+					require.True(t, ok)
+					require.Equal(t, number, value)
+				}
+			})
+			t.Run("Invalid Heaps", func(t *testing.T) {
+				h := implementation.newHeap(less, 1)
+
+				require.False(t, h.Meld(nil))
+				require.False(t, h.Meld(h))
+
+				for _, other := range meldableHeaps {
+					if other.name == implementation.name {
+						continue
+					}
+					require.False(t, h.Meld(other.newHeap(less, 0)))
+				}
+
+				require.Equal(t, 1, h.Size())
+			})
+		})
+	}
+}
+
+func TestFibonacciHeap(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	t.Run("DecreaseKey", func(t *testing.T) {
+		h := NewFibonacciHeap[int](less)
+
+		nodes := make([]*FibonacciNode[int], 10)
+		for i := range nodes {
+			nodes[i] = h.InsertHandle(i * 10)
+		}
+
+		// extract once so that the heap is consolidated into trees
+		value, ok := h.Extract()
+		require.True(t, ok)
+		require.Equal(t, 0, value)
+
+		require.True(t, h.DecreaseKey(nodes[9], -1))
+		value, ok = h.Peek()
+		require.True(t, ok)
+		require.Equal(t, -1, value)
+
+		// increasing the key is not allowed
+		require.False(t, h.DecreaseKey(nodes[5], 1000))
+		// decreasing to the same key is allowed
+		require.True(t, h.DecreaseKey(nodes[5], 50))
+		// extracted nodes are not in the heap anymore
+		require.False(t, h.DecreaseKey(nodes[0], -10))
+
+		value, ok = nodes[9].Value()
+		require.True(t, ok)
+		require.Equal(t, -1, value)
+
+		_, ok = nodes[0].Value()
+		require.False(t, ok)
+
+		expected := []int{-1, 10, 20, 30, 40, 50, 60, 70, 80}
+		for _, number := range expected {
+			value, ok := h.Extract()
+			require.True(t, ok)
+			require.Equal(t, number, value)
+		}
+	})
+	t.Run("Delete", func(t *testing.T) {
+		h := NewFibonacciHeap[int](less)
+
+		nodes := make([]*FibonacciNode[int], 10)
+		for i := range nodes {
+			nodes[i] = h.InsertHandle(i)
+		}
+		h.Extract()
+
+		for _, i := range []int{1, 5, 9} {
+			value, ok := h.Delete(nodes[i])
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+		require.Equal(t, 6, h.Size())
+
+		_, ok := h.Delete(nodes[1])
+		require.False(t, ok)
+		_, ok = h.Delete(nil)
+		require.False(t, ok)
+
+		expected := []int{2, 3, 4, 6, 7, 8}
+		for _, number := range expected {
+			value, ok := h.Extract()
+			require.True(t, ok)
+			require.Equal(t, number, value)
+		}
+	})
+	t.Run("Nodes After Meld", func(t *testing.T) {
+		h := NewFibonacciHeap[int](less, 10, 20)
+		other := NewFibonacciHeap[int](less)
+		node := other.InsertHandle(30)
+
+		require.True(t, h.Meld(other))
+
+		// the node now belongs to the heap it was melded into
+		require.False(t, other.DecreaseKey(node, 0))
+		require.True(t, h.DecreaseKey(node, 0))
+
+		// nodes inserted into the other heap after the meld belong to it
+		otherNode := other.InsertHandle(5)
+		require.False(t, h.DecreaseKey(otherNode, 0))
+		require.True(t, other.DecreaseKey(otherNode, 1))
+
+		// meld the heap into a third heap
+		third := NewFibonacciHeap[int](less)
+		require.True(t, third.Meld(h))
+
+		_, ok := h.Delete(node)
+		require.False(t, ok)
+		value, ok := third.Delete(node)
+		require.True(t, ok)
+		require.Equal(t, 0, value)
+
+		value, ok = third.Peek()
+		require.True(t, ok)
+		require.Equal(t, 10, value)
+		require.Equal(t, 2, third.Size())
+	})
+	t.Run("Randomized", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		h := NewFibonacciHeap[int](less)
+
+		// the expected content of the heap is tracked in a map from node to value
+		expected := make(map[*FibonacciNode[int]]int)
+		for i := 0; i < 5000; i++ {
+			switch operation := random.Intn(5); {
+			case operation <= 1 || len(expected) == 0:
+				value := random.Intn(1000000)
+				expected[h.InsertHandle(value)] = value
+			case operation == 2:
+				for node, value := range expected {
+					value -= random.Intn(1000)
+					require.True(t, h.DecreaseKey(node, value))
+					expected[node] = value
+					break
+				}
+			case operation == 3:
+				for node, value := range expected {
+					deleted, ok := h.Delete(node)
+					require.True(t, ok)
+					require.Equal(t, value, deleted)
+					delete(expected, node)
+					break
+				}
+			default:
+				minimum, ok := h.Extract()
+				require.True(t, ok)
+				for node, value := range expected {
+					if _, ok := node.Value(); !ok {
+						require.Equal(t, minimum, value)
+						delete(expected, node)
+						continue
+					}
+					require.LessOrEqual(t, minimum, value)
+				}
+			}
+
+			require.Equal(t, len(expected), h.Size())
+		}
+	})
+}
+
+// graph is a directed graph with weighted edges, used to benchmark heaps on Dijkstra-shaped workloads
+type graph [][]struct{ to, weight int }
+
+// newRandomGraph creates a random graph with the given number of vertices and edges per vertex
+func newRandomGraph(vertices, edgesPerVertex int) graph {
+	random := rand.New(rand.NewSource(1))
+
+	g := make(graph, vertices)
+	for from := range g {
+		for i := 0; i < edgesPerVertex; i++ {
+			g[from] = append(g[from], struct{ to, weight int }{
+				to:     random.Intn(vertices),
+				weight: random.Intn(1000) + 1,
+			})
+		}
+	}
+
+	return g
+}
+
+// vertexDistance is a vertex paired with its tentative distance from the source
+type vertexDistance struct {
+	vertex   int
+	distance int
+}
+
+// dijkstraBinaryHeap computes the shortest distances from vertex 0 using the binary heap with handles
+func dijkstraBinaryHeap(g graph) []int {
+	h := NewHeap[vertexDistance](func(t1, t2 vertexDistance) bool {
+		return t1.distance < t2.distance
+	})
+
+	distances := make([]int, len(g))
+	handles := make([]*Handle[vertexDistance], len(g))
+	for vertex := range g {
+		distances[vertex] = math.MaxInt
+	}
+
+	distances[0] = 0
+	handles[0] = h.InsertHandle(vertexDistance{vertex: 0, distance: 0})
+	for h.Size() > 0 {
+		current, _ := h.Extract()
+		for _, edge := range g[current.vertex] {
+			distance := current.distance + edge.weight
+			if distance >= distances[edge.to] {
+				continue
+			}
+
+			distances[edge.to] = distance
+			next := vertexDistance{vertex: edge.to, distance: distance}
+			if !h.Update(handles[edge.to], next) {
+				handles[edge.to] = h.InsertHandle(next)
+			}
+		}
+	}
+
+	return distances
+}
+
+// dijkstraFibonacciHeap computes the shortest distances from vertex 0 using the Fibonacci heap
+func dijkstraFibonacciHeap(g graph) []int {
+	h := NewFibonacciHeap[vertexDistance](func(t1, t2 vertexDistance) bool {
+		return t1.distance < t2.distance
+	})
+
+	distances := make([]int, len(g))
+	nodes := make([]*FibonacciNode[vertexDistance], len(g))
+	for vertex := range g {
+		distances[vertex] = math.MaxInt
+	}
+
+	distances[0] = 0
+	nodes[0] = h.InsertHandle(vertexDistance{vertex: 0, distance: 0})
+	for h.Size() > 0 {
+		current, _ := h.Extract()
+		for _, edge := range g[current.vertex] {
+			distance := current.distance + edge.weight
+			if distance >= distances[edge.to] {
+				continue
+			}
+
+			distances[edge.to] = distance
+			next := vertexDistance{vertex: edge.to, distance: distance}
+			if !h.DecreaseKey(nodes[edge.to], next) {
+				nodes[edge.to] = h.InsertHandle(next)
+			}
+		}
+	}
+
+	return distances
+}
+
+func TestDijkstra(t *testing.T) {
+	g := newRandomGraph(1000, 10)
+
+	require.Equal(t, dijkstraBinaryHeap(g), dijkstraFibonacciHeap(g))
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	graphs := []struct {
+		name  string
+		graph graph
+	}{
+		{name: "sparse", graph: newRandomGraph(10000, 4)},
+		{name: "dense", graph: newRandomGraph(2000, 200)},
+	}
+
+	for _, g := range graphs {
+		b.Run(g.name+"/binary heap", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dijkstraBinaryHeap(g.graph)
+			}
+		})
+		b.Run(g.name+"/fibonacci heap", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dijkstraFibonacciHeap(g.graph)
+			}
+		})
+	}
+}
+
+func TestNewMinMaxHeap(t *testing.T) {
+	t.Run("Min Max Heap Without Comparator", func(t *testing.T) {
+		h := NewMinMaxHeap[int](nil)
+
+		require.Nil(t, h)
+	})
+	t.Run("Min Max Heap Without Initial Values", func(t *testing.T) {
+		h := NewMinMaxHeap[int](func(t1, t2 int) bool {
+			return t1 < t2
+		})
+
+		require.NotNil(t, h)
+		require.Equal(t, 0, h.Size())
+
+		for _, f := range []func() (int, bool){h.PeekMin, h.PeekMax, h.ExtractMin, h.ExtractMax} {
+			value, ok := f()
+			require.False(t, ok)
+			require.Zero(t, value)
+		}
+	})
+	t.Run("Min Max Heap With Initial Values", func(t *testing.T) {
+		for size := 1; size <= 100; size++ {
+			values := rand.New(rand.NewSource(int64(size))).Perm(size)
+
+			h := NewMinMaxHeap[int](func(t1, t2 int) bool {
+				return t1 < t2
+			}, values...)
+			require.Equal(t, size, h.Size())
+
+			// alternate between the two ends
+			low, high := 0, size-1
+			for low <= high {
+				value, ok := h.PeekMin()
+				require.True(t, ok)
+				require.Equal(t, low, value)
+
+				value, ok = h.PeekMax()
+				require.True(t, ok)
+				require.Equal(t, high, value)
+
+				value, ok = h.ExtractMin()
+				require.True(t, ok)
+				require.Equal(t, low, value)
+				low++
+
+				if low > high {
+					break
+				}
+
+				value, ok = h.ExtractMax()
+				require.True(t, ok)
+				require.Equal(t, high, value)
+				high--
+			}
+
+			require.Equal(t, 0, h.Size())
+		}
+	})
+}
+
+func TestMinMaxHeap_Randomized(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	h := NewMinMaxHeap[int](func(t1, t2 int) bool {
+		return t1 < t2
+	})
+
+	// the expected content of the heap is kept sorted
+	var expected []int
+	for i := 0; i < 10000; i++ {
+		switch operation := random.Intn(4); {
+		case operation <= 1 || len(expected) == 0:
+			value := random.Intn(500)
+			h.Insert(value)
+
+			index, _ := slices.BinarySearch(expected, value)
+			expected = slices.Insert(expected, index, value)
+		case operation == 2:
+			value, ok := h.ExtractMin()
+			require.True(t, ok)
+			require.Equal(t, expected[0], value)
+			expected = expected[1:]
+		default:
+			value, ok := h.ExtractMax()
+			require.True(t, ok)
+			require.Equal(t, expected[len(expected)-1], value)
+			expected = expected[:len(expected)-1]
+		}
+
+		require.Equal(t, len(expected), h.Size())
+		if len(expected) > 0 {
+			minimum, ok := h.PeekMin()
+			require.True(t, ok)
+			require.Equal(t, expected[0], minimum)
+
+			maximum, ok := h.PeekMax()
+			require.True(t, ok)
+			require.Equal(t, expected[len(expected)-1], maximum)
+		}
+	}
+}
+
+func TestNewTopK(t *testing.T) {
+	greater := func(t1, t2 int) bool {
+		return t1 > t2
+	}
+
+	t.Run("Top K Without Comparator", func(t *testing.T) {
+		require.Nil(t, NewTopK[int](3, nil))
+	})
+	t.Run("Top K With Non Positive K", func(t *testing.T) {
+		require.Nil(t, NewTopK[int](0, greater))
+		require.Nil(t, NewTopK[int](-1, greater))
+	})
+	t.Run("Empty Top K", func(t *testing.T) {
+		tk := NewTopK[int](3, greater)
+
+		require.NotNil(t, tk)
+		require.Equal(t, 0, tk.Size())
+		require.Equal(t, 3, tk.K())
+		require.Empty(t, tk.Sorted())
+	})
+}
+
+func TestTopK_Offer(t *testing.T) {
+	t.Run("Largest", func(t *testing.T) {
+		tk := NewTopK[int](3, func(t1, t2 int) bool {
+			return t1 > t2
+		})
+
+		require.True(t, tk.Offer(5))
+		require.True(t, tk.Offer(1))
+		require.True(t, tk.Offer(3))
+		require.Equal(t, []int{5, 3, 1}, tk.Sorted())
+
+		require.True(t, tk.Offer(4))
+		require.False(t, tk.Offer(2))
+		require.False(t, tk.Offer(3))
+		require.True(t, tk.Offer(10))
+
+		require.Equal(t, 3, tk.Size())
+		require.Equal(t, []int{10, 5, 4}, tk.Sorted())
+
+		// Sorted does not change the top-k
+		require.Equal(t, []int{10, 5, 4}, tk.Sorted())
+	})
+	t.Run("Smallest Of A Stream", func(t *testing.T) {
+		const k = 10
+		tk := NewTopK[int](k, func(t1, t2 int) bool {
+			return t1 < t2
+		})
+
+		values := rand.New(rand.NewSource(1)).Perm(10000)
+		for _, value := range values {
+			tk.Offer(value)
+		}
+
+		expected := make([]int, k)
+		for i := range expected {
+			expected[i] = i
+		}
+		require.Equal(t, expected, tk.Sorted())
+	})
+}
+
+func TestTopK_Merge(t *testing.T) {
+	greater := func(t1, t2 int) bool {
+		return t1 > t2
+	}
+
+	// split a stream between a few top-k instances and merge them
+	random := rand.New(rand.NewSource(1))
+	values := random.Perm(1000)
+
+	const k = 5
+	parts := make([]TopK[int], 4)
+	for i := range parts {
+		parts[i] = NewTopK[int](k, greater)
+	}
+	for i, value := range values {
+		parts[i%len(parts)].Offer(value)
+	}
+
+	merged := NewTopK[int](k, greater)
+	for _, part := range parts {
+		before := part.Sorted()
+		merged.Merge(part)
+
+		// the top-k that was merged is left unchanged
+		require.Equal(t, before, part.Sorted())
+	}
+	merged.Merge(nil)
+
+	require.Equal(t, []int{999, 998, 997, 996, 995}, merged.Sorted())
+
+	// merging a top-k with itself does not duplicate its elements
+	self := NewTopK[int](3, greater)
+	for _, value := range []int{3, 5, 4} {
+		self.Offer(value)
+	}
+	self.Merge(self)
+	require.Equal(t, []int{5, 4, 3}, self.Sorted())
+
+	// a typed nil top-k is ignored like an untyped nil
+	var typedNil *topK[int]
+	require.NotPanics(t, func() {
+		self.Merge(typedNil)
+	})
+	require.Equal(t, []int{5, 4, 3}, self.Sorted())
+}
+
+func TestStableHeap(t *testing.T) {
+	// job is an element whose priority is compared, while its id records the insertion order
+	type job struct {
+		priority int
+		id       int
+	}
+
+	less := func(t1, t2 job) bool {
+		return t1.priority < t2.priority
+	}
+
+	t.Run("Initial Values", func(t *testing.T) {
+		jobs := []job{{1, 0}, {0, 1}, {1, 2}, {0, 3}, {1, 4}, {0, 5}}
+		h := NewStableHeap(less, jobs...)
+
+		expected := []job{{0, 1}, {0, 3}, {0, 5}, {1, 0}, {1, 2}, {1, 4}}
+		for _, j := range expected {
+			value, ok := h.Peek()
+			require.True(t, ok)
+			require.Equal(t, j, value)
+
+			value, ok = h.Extract()
+			require.True(t, ok)
+			require.Equal(t, j, value)
+		}
+	})
+	t.Run("Sorted", func(t *testing.T) {
+		jobs := []job{{1, 0}, {0, 1}, {1, 2}, {0, 3}, {1, 4}, {0, 5}}
+		h := NewStableHeap(less, jobs...)
+
+		var values []job
+		for j := range h.Sorted() {
+			values = append(values, j)
+		}
+
+		require.Equal(t, []job{{0, 1}, {0, 3}, {0, 5}, {1, 0}, {1, 2}, {1, 4}}, values)
+		require.Equal(t, len(jobs), h.Size())
+	})
+	t.Run("Randomized", func(t *testing.T) {
+		operations := 1000000
+		if testing.Short() {
+			operations = 100000
+		}
+
+		const priorities = 8
+		random := rand.New(rand.NewSource(1))
+		h := NewStableHeap(less)
+
+		// the expected content of the heap is kept in a FIFO queue of ids for each priority
+		var expected [priorities][]int
+		size, nextID := 0, 0
+		for i := 0; i < operations; i++ {
+			if random.Intn(2) == 0 || size == 0 {
+				priority := random.Intn(priorities)
+				h.Insert(job{priority: priority, id: nextID})
+
+				expected[priority] = append(expected[priority], nextID)
+				nextID++
+				size++
+				continue
+			}
+
+			value, ok := h.Extract()
+			require.True(t, ok)
+
+			priority := 0
+			for len(expected[priority]) == 0 {
+				priority++
+			}
+			if value.priority != priority || value.id != expected[priority][0] {
+				require.Equal(t, job{priority: priority, id: expected[priority][0]}, value)
+			}
+
+			expected[priority] = expected[priority][1:]
+			size--
+		}
+
+		require.Equal(t, size, h.Size())
+	})
+}
+
+func TestSort(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	testCases := []struct {
+		name string
+		data []int
+	}{
+		{name: "Nil Slice", data: nil},
+		{name: "Single Element", data: []int{1}},
+		{name: "Two Elements", data: []int{2, 1}},
+		{name: "Duplicates", data: data},
+		{name: "Sorted", data: []int{1, 2, 3, 4, 5}},
+		{name: "Reversed", data: []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := slices.Clone(tc.data)
+			Sort(s, less)
+
+			expected := slices.Clone(tc.data)
+			slices.Sort(expected)
+			require.Equal(t, expected, s)
+		})
+	}
+
+	t.Run("Randomized", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 100; i++ {
+			s := random.Perm(random.Intn(500))
+			for j := range s {
+				s[j] %= 50
+			}
+
+			expected := slices.Clone(s)
+			slices.Sort(expected)
+
+			Sort(s, func(t1, t2 int) bool {
+				return t1 < t2
+			})
+			require.Equal(t, expected, s)
+		}
+	})
+}
+
+func TestPartialSort(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	t.Run("Non Positive K", func(t *testing.T) {
+		s := slices.Clone(data)
+		PartialSort(s, 0, less)
+		require.Equal(t, data, s)
+
+		PartialSort(s, -1, less)
+		require.Equal(t, data, s)
+	})
+	t.Run("K Larger Than Slice", func(t *testing.T) {
+		s := slices.Clone(data)
+		PartialSort(s, len(s)+1, less)
+		require.True(t, slices.IsSorted(s))
+	})
+	t.Run("Randomized", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 100; i++ {
+			s := random.Perm(random.Intn(500) + 1)
+			for j := range s {
+				s[j] %= 50
+			}
+			k := random.Intn(len(s)) + 1
+
+			expected := slices.Clone(s)
+			slices.Sort(expected)
+
+			PartialSort(s, k, less)
+			require.Equal(t, expected[:k], s[:k])
+
+			// the rest of the slice keeps the remaining elements
+			slices.Sort(s[k:])
+			require.Equal(t, expected[k:], s[k:])
+		}
+	})
+}
+
+func TestSelect(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	t.Run("Out Of Range", func(t *testing.T) {
+		_, ok := Select([]int{}, 0, less)
+		require.False(t, ok)
+
+		_, ok = Select(slices.Clone(data), -1, less)
+		require.False(t, ok)
+
+		_, ok = Select(slices.Clone(data), len(data), less)
+		require.False(t, ok)
+	})
+	t.Run("Randomized", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 200; i++ {
+			s := random.Perm(random.Intn(500) + 1)
+			for j := range s {
+				s[j] %= 50
+			}
+			k := random.Intn(len(s))
+
+			expected := slices.Clone(s)
+			slices.Sort(expected)
+
+			value, ok := Select(s, k, less)
+			require.True(t, ok)
+			require.Equal(t, expected[k], value)
+			require.Equal(t, expected[k], s[k])
+
+			for _, number := range s[:k] {
+				require.LessOrEqual(t, number, value)
+			}
+			for _, number := range s[k+1:] {
+				require.GreaterOrEqual(t, number, value)
+			}
+		}
+	})
+}
+
+func TestNSmallest_NLargest(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	testCases := []struct {
+		name     string
+		n        int
+		smallest []int
+		largest  []int
+	}{
+		{name: "Non Positive N", n: 0, smallest: nil, largest: nil},
+		{name: "Some Elements", n: 3, smallest: []int{-53, -1, 0}, largest: []int{68, 34, 7}},
+		{name: "All Elements", n: len(data) + 5, smallest: []int{-53, -1, 0, 0, 2, 4, 6, 7, 34, 68}, largest: []int{68, 34, 7, 6, 4, 2, 0, 0, -1, -53}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.smallest, NSmallest(slices.Values(data), tc.n, less))
+			require.Equal(t, tc.largest, NLargest(slices.Values(data), tc.n, less))
+		})
+	}
+
+	t.Run("Empty Sequence", func(t *testing.T) {
+		require.Empty(t, NSmallest(slices.Values([]int{}), 3, less))
+		require.Empty(t, NLargest(slices.Values([]int{}), 3, less))
+	})
+}
+
+func BenchmarkSort(b *testing.B) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	random := rand.New(rand.NewSource(1))
+	input := make([]int, 100000)
+	for i := range input {
+		input[i] = random.Int()
+	}
+	s := make([]int, len(input))
+
+	b.Run("heap.Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, input)
+			Sort(s, less)
+		}
+	})
+	b.Run("heap.PartialSort k=100", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, input)
+			PartialSort(s, 100, less)
+		}
+	})
+	b.Run("heap.Select k=n/2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, input)
+			Select(s, len(s)/2, less)
+		}
+	})
+	b.Run("heap.NSmallest n=100", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NSmallest(slices.Values(input), 100, less)
+		}
+	})
+	b.Run("slices.SortFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, input)
+			slices.SortFunc(s, cmp.Compare[int])
+		}
+	})
+	b.Run("slices.Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, input)
+			slices.Sort(s)
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	testCases := []struct {
+		name     string
+		seqs     [][]int
+		merged   []int
+		distinct []int
+	}{
+		{name: "No Sequences", seqs: nil, merged: nil, distinct: nil},
+		{name: "Empty Sequences", seqs: [][]int{{}, {}}, merged: nil, distinct: nil},
+		{name: "Single Sequence", seqs: [][]int{{1, 1, 2}}, merged: []int{1, 1, 2}, distinct: []int{1, 2}},
+		{
+			name:     "Several Sequences",
+			seqs:     [][]int{{1, 4, 7}, {}, {2, 2, 5, 8, 9}, {0, 3, 6}},
+			merged:   []int{0, 1, 2, 2, 3, 4, 5, 6, 7, 8, 9},
+			distinct: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+		{
+			name:     "Overlapping Sequences",
+			seqs:     [][]int{{1, 2, 3}, {1, 2, 3}, {3, 3, 4}},
+			merged:   []int{1, 1, 2, 2, 3, 3, 3, 3, 4},
+			distinct: []int{1, 2, 3, 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			seqs := make([]iter.Seq[int], len(tc.seqs))
+			for i, s := range tc.seqs {
+				seqs[i] = slices.Values(s)
+			}
+
+			require.Equal(t, tc.merged, slices.Collect(Merge(less, seqs...)))
+			require.Equal(t, tc.distinct, slices.Collect(MergeDistinct(less, seqs...)))
+		})
+	}
+
+	t.Run("Stability", func(t *testing.T) {
+		type entry struct {
+			key    int
+			source string
+		}
+		byKey := func(t1, t2 entry) bool {
+			return t1.key < t2.key
+		}
+
+		a := []entry{{1, "a1"}, {2, "a2"}, {2, "a3"}}
+		b := []entry{{1, "b1"}, {2, "b2"}}
+		c := []entry{{0, "c1"}, {2, "c2"}}
+
+		merged := slices.Collect(Merge(byKey, slices.Values(a), slices.Values(b), slices.Values(c)))
+		require.Equal(t, []entry{
+			{0, "c1"}, {1, "a1"}, {1, "b1"}, {2, "a2"}, {2, "a3"}, {2, "b2"}, {2, "c2"},
+		}, merged)
+
+		distinct := slices.Collect(MergeDistinct(byKey, slices.Values(a), slices.Values(b), slices.Values(c)))
+		require.Equal(t, []entry{{0, "c1"}, {1, "a1"}, {2, "a2"}}, distinct)
+	})
+	t.Run("Break Stops Sequences", func(t *testing.T) {
+		// counting returns an infinite sorted sequence starting at start, which reports when it is stopped
+		stopped := 0
+		counting := func(start int) iter.Seq[int] {
+			return func(yield func(int) bool) {
+				defer func() {
+					stopped += 1
+				}()
+
+				for i := start; ; i += 2 {
+					if !yield(i) {
+						return
+					}
+				}
+			}
+		}
+
+		var merged []int
+		for t := range Merge(less, counting(0), counting(1)) {
+			if t == 10 {
+				break
+			}
+			merged = append(merged, t)
+		}
+
+		require.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, merged)
+		require.Equal(t, 2, stopped)
+	})
+	t.Run("Randomized", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		for i := 0; i < 50; i++ {
+			var expected []int
+			seqs := make([]iter.Seq[int], random.Intn(20))
+			for j := range seqs {
+				s := make([]int, random.Intn(100))
+				for k := range s {
+					s[k] = random.Intn(1000)
+				}
+				slices.Sort(s)
+
+				seqs[j] = slices.Values(s)
+				expected = append(expected, s...)
+			}
+			slices.Sort(expected)
+
+			require.Equal(t, expected, slices.Collect(Merge(less, seqs...)))
+			require.Equal(t, slices.Compact(expected), slices.Collect(MergeDistinct(less, seqs...)))
+		}
+	})
+}
+
+func TestMergeLists(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	singly := linkedlist.NewSinglyLinkedList[int]()
+	for _, number := range []int{1, 4, 7} {
+		singly.AddLast(number)
+	}
+
+	doubly := linkedlist.NewDoublyLinkedList[int]()
+	for _, number := range []int{2, 4, 8} {
+		doubly.AddLast(number)
+	}
+
+	merged := slices.Collect(MergeLists(less, singly, nil, doubly, linkedlist.NewSinglyLinkedList[int]()))
+	require.Equal(t, []int{1, 2, 4, 4, 7, 8}, merged)
+
+	// the lists are not changed
+	require.Equal(t, 3, singly.Size())
+	require.Equal(t, 3, doubly.Size())
+
+	require.Empty(t, slices.Collect(MergeLists[int](less)))
+}
+
+func TestFromSeq(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	require.Nil(t, FromSeq(nil, slices.Values(data)))
+	require.Zero(t, FromSeq(less, slices.Values([]int{})).Size())
+
+	h := FromSeq(less, slices.Values(data))
+	require.Equal(t, len(data), h.Size())
+
+	expected := slices.Clone(data)
+	slices.Sort(expected)
+	require.Equal(t, expected, slices.Collect(h.Drain()))
+}
+
+func TestHeap_ToSlice(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	for _, heap := range heaps {
+		t.Run(heap.name, func(t *testing.T) {
+			h := heap.newHeap(less)
+			require.Equal(t, []int{}, h.ToSlice())
+			require.Nil(t, h.AppendTo(nil))
+
+			h = heap.newHeap(less, data...)
+			require.Equal(t, slices.Collect(h.All()), h.ToSlice())
+			require.ElementsMatch(t, data, h.ToSlice())
+
+			appended := h.AppendTo([]int{1000})
+			require.Equal(t, 1000, appended[0])
+			require.ElementsMatch(t, data, appended[1:])
+
+			// the heap is not changed
+			require.Equal(t, len(data), h.Size())
+		})
+	}
+}
+
+func TestFrom_FromSlice(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	require.Nil(t, From(nil, 1, 2))
+	require.Nil(t, FromSlice(nil, data))
+
+	expected := slices.Clone(data)
+	slices.Sort(expected)
+
+	require.Equal(t, expected, slices.Collect(From(less, data...).Drain()))
+
+	// the input slice is copied
+	input := slices.Clone(data)
+	h := FromSlice(less, input)
+	input[0] = math.MinInt
+	require.Equal(t, expected, slices.Collect(h.Drain()))
+}
+
+func TestMinMaxHeap_Conversions(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+
+	require.Nil(t, MinMaxHeapFrom(nil, 1, 2))
+	require.Nil(t, MinMaxHeapFromSlice(nil, data))
+	require.Nil(t, MinMaxHeapFromSeq(nil, slices.Values(data)))
+
+	empty := MinMaxHeapFromSeq(less, slices.Values([]int{}))
+	require.Zero(t, empty.Size())
+	require.Equal(t, []int{}, empty.ToSlice())
+	require.Nil(t, empty.AppendTo(nil))
+
+	expected := slices.Clone(data)
+	slices.Sort(expected)
+
+	// the input slice is copied
+	input := slices.Clone(data)
+	fromSlice := MinMaxHeapFromSlice(less, input)
+	input[0] = math.MinInt
+
+	for _, h := range []MinMaxHeap[int]{
+		MinMaxHeapFrom(less, data...),
+		fromSlice,
+		MinMaxHeapFromSeq(less, slices.Values(data)),
+	} {
+		require.ElementsMatch(t, data, h.ToSlice())
+
+		appended := h.AppendTo([]int{1000})
+		require.Equal(t, 1000, appended[0])
+		require.ElementsMatch(t, data, appended[1:])
+
+		// the heap is not changed
+		require.Equal(t, len(data), h.Size())
+
+		for _, want := range expected {
+			got, ok := h.ExtractMin()
+			require.True(t, ok)
+			require.Equal(t, want, got)
+		}
+	}
+}
+
+func TestTopK_Conversions(t *testing.T) {
+	greater := func(t1, t2 int) bool {
+		return t1 > t2
+	}
+
+	require.Nil(t, TopKFrom(3, nil, 1, 2))
+	require.Nil(t, TopKFromSlice(0, greater, data))
+	require.Nil(t, TopKFromSeq(-1, greater, slices.Values(data)))
+
+	empty := TopKFromSeq(3, greater, slices.Values([]int{}))
+	require.Zero(t, empty.Size())
+	require.Equal(t, []int{}, empty.ToSlice())
+	require.Nil(t, empty.AppendTo(nil))
+
+	expected := slices.Clone(data)
+	slices.Sort(expected)
+	slices.Reverse(expected)
+
+	for _, k := range []int{1, 5, len(data), len(data) + 10} {
+		best := expected[:min(k, len(expected))]
+
+		// the input slice is not referenced
+		input := slices.Clone(data)
+		fromSlice := TopKFromSlice(k, greater, input)
+		for i := range input {
+			input[i] = math.MinInt
+		}
+
+		for _, tk := range []TopK[int]{
+			TopKFrom(k, greater, data...),
+			fromSlice,
+			TopKFromSeq(k, greater, slices.Values(data)),
+		} {
+			require.Equal(t, k, tk.K())
+			require.Equal(t, best, tk.Sorted())
+			require.ElementsMatch(t, best, tk.ToSlice())
+
+			appended := tk.AppendTo([]int{1000})
+			require.Equal(t, 1000, appended[0])
+			require.ElementsMatch(t, best, appended[1:])
+
+			// the heap property holds for further offers
+			require.True(t, tk.Offer(math.MaxInt))
+			offered := append([]int{math.MaxInt}, best...)
+			require.Equal(t, offered[:min(k, len(offered))], tk.Sorted())
+		}
+	}
+}
+
+func TestHeap_Encoding(t *testing.T) {
+	less := func(t1, t2 int) bool {
+		return t1 < t2
+	}
+	greater := func(t1, t2 int) bool {
+		return t1 > t2
+	}
+
+	expected := slices.Clone(data)
+	slices.Sort(expected)
+
+	h := NewHeap(less, data...)
+
+	encoded, err := json.Marshal(h)
+	require.NoError(t, err)
+
+	// the comparator is given by the heap the data is decoded into
+	decoded := NewHeap(less, 1000)
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	require.Equal(t, expected, slices.Collect(decoded.Sorted()))
+
+	// null and invalid data leave the heap unchanged
+	require.NoError(t, json.Unmarshal([]byte(`null`), decoded))
+	require.Error(t, json.Unmarshal([]byte(`[1, "a"]`), decoded))
+	require.Equal(t, expected, slices.Collect(decoded.Sorted()))
+
+	// the heap property is rebuilt with the comparator of the decoding heap
+	reversed := slices.Clone(expected)
+	slices.Reverse(reversed)
+
+	decoded = NewHeap(greater)
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	require.Equal(t, reversed, slices.Collect(decoded.Drain()))
+
+	var buffer bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buffer).Encode(h))
+	decoded = NewHeap(less)
+	require.NoError(t, gob.NewDecoder(&buffer).Decode(decoded))
+	require.Equal(t, expected, slices.Collect(decoded.Sorted()))
+
+	binary, err := h.MarshalBinary()
+	require.NoError(t, err)
+	decoded = NewHeap(less)
+	require.NoError(t, decoded.UnmarshalBinary(binary))
+	require.Error(t, decoded.UnmarshalBinary([]byte("invalid")))
+	require.Equal(t, expected, slices.Collect(decoded.Sorted()))
+
+	// the handles of the replaced elements are invalidated, new handles work as usual
+	handle := decoded.InsertHandle(-1000)
+	require.NoError(t, decoded.UnmarshalBinary(binary))
+	_, ok := handle.Value()
+	require.False(t, ok)
+	require.False(t, decoded.Update(handle, 0))
+
+	handle = decoded.InsertHandle(-1000)
+	require.True(t, decoded.Update(handle, 1000))
+	require.Equal(t, append(slices.Clone(expected), 1000), slices.Collect(decoded.Drain()))
+
+	// an empty heap encodes to an empty one
+	binary, err = NewHeap(less).MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, decoded.UnmarshalBinary(binary))
+	require.Zero(t, decoded.Size())
+	decoded.Insert(1)
+	require.Equal(t, []int{1}, decoded.ToSlice())
+}
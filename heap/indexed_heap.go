@@ -0,0 +1,163 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+)
+
+// indexedEntry represents a key-value pair stored in an indexed heap
+type indexedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// indexedHeap is an implementation of the IndexedHeap interface
+//
+// it stores its entries in a heap with handles and keeps the handle of each key in a map
+type indexedHeap[K comparable, V any] struct {
+	heap    *heap[indexedEntry[K, V]]
+	handles map[K]*Handle[indexedEntry[K, V]]
+}
+
+// Push adds a key with the given value to the heap
+//
+// returns ok = false if the key is already in the heap, in that case the heap is not changed
+// O(log(n))
+func (h *indexedHeap[K, V]) Push(key K, v V) (ok bool) {
+	if _, exists := h.handles[key]; exists {
+		return
+	}
+
+	h.handles[key] = h.heap.InsertHandle(indexedEntry[K, V]{key: key, value: v})
+
+	return true
+}
+
+// Update replaces the value of the given key and restores the heap property
+//
+// returns ok = false if the key is not in the heap
+// O(log(n))
+func (h *indexedHeap[K, V]) Update(key K, v V) (ok bool) {
+	handle, exists := h.handles[key]
+	if !exists {
+		return
+	}
+
+	return h.heap.Update(handle, indexedEntry[K, V]{key: key, value: v})
+}
+
+// Delete removes the given key from the heap and returns its value
+//
+// returns ok = false if the key is not in the heap
+// O(log(n))
+func (h *indexedHeap[K, V]) Delete(key K) (v V, ok bool) {
+	handle, exists := h.handles[key]
+	if !exists {
+		return
+	}
+
+	delete(h.handles, key)
+	entry, _ := h.heap.Remove(handle)
+
+	return entry.value, true
+}
+
+// Contains reports whether the given key is in the heap
+//
+// O(1)
+func (h *indexedHeap[K, V]) Contains(key K) bool {
+	_, exists := h.handles[key]
+	return exists
+}
+
+// Get returns the value of the given key
+//
+// returns ok = false if the key is not in the heap
+// O(1)
+func (h *indexedHeap[K, V]) Get(key K) (v V, ok bool) {
+	handle, exists := h.handles[key]
+	if !exists {
+		return
+	}
+
+	entry, _ := handle.Value()
+
+	return entry.value, true
+}
+
+// Peek returns the root key and its value without removing them
+//
+// returns ok = false if heap is empty
+func (h *indexedHeap[K, V]) Peek() (key K, v V, ok bool) {
+	entry, ok := h.heap.Peek()
+	if !ok {
+		return
+	}
+
+	return entry.key, entry.value, true
+}
+
+// Pop removes and returns the root key and its value
+//
+// returns ok = false if heap is empty
+// O(log(n))
+func (h *indexedHeap[K, V]) Pop() (key K, v V, ok bool) {
+	entry, ok := h.heap.Extract()
+	if !ok {
+		return
+	}
+
+	delete(h.handles, entry.key)
+
+	return entry.key, entry.value, true
+}
+
+// Size returns the number of keys in the heap
+func (h *indexedHeap[K, V]) Size() int {
+	return h.heap.Size()
+}
+
+// NewIndexedHeap creates a new indexed heap whose keys are ordered by their values
+// using the given comparator
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap:
+// - For a max-heap, less should return true if the first argument is greater than the second
+// - For a min-heap, less should return true if the first argument is less than the second
+//
+// Example usage:
+// - Min-Heap of job priorities: NewIndexedHeap[string, int](func(a, b int) bool { return a < b })
+func NewIndexedHeap[K comparable, V any](less func(v1, v2 V) bool) (h IndexedHeap[K, V]) {
+	if less == nil {
+		return
+	}
+
+	return &indexedHeap[K, V]{
+		heap: &heap[indexedEntry[K, V]]{
+			data: make([]indexedEntry[K, V], 0),
+			comparator: func(t1, t2 indexedEntry[K, V]) bool {
+				return less(t1.value, t2.value)
+			},
+		},
+		handles: make(map[K]*Handle[indexedEntry[K, V]]),
+	}
+}
+
+// NewMinIndexedHeap creates a new indexed heap whose keys are ordered by their values of an ordered type,
+// the key with the smallest value being the root
+//
+// Example usage:
+// - NewMinIndexedHeap[string, int]()
+func NewMinIndexedHeap[K comparable, V cmp.Ordered]() IndexedHeap[K, V] {
+	return NewIndexedHeap[K](comparator.Less[V])
+}
+
+// NewMaxIndexedHeap creates a new indexed heap whose keys are ordered by their values of an ordered type,
+// the key with the largest value being the root
+//
+// Example usage:
+// - NewMaxIndexedHeap[string, int]()
+func NewMaxIndexedHeap[K comparable, V cmp.Ordered]() IndexedHeap[K, V] {
+	return NewIndexedHeap[K](comparator.Greater[V])
+}
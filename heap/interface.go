@@ -1,5 +1,12 @@
 package heap
 
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"iter"
+)
+
 // Heap defines the interface for a generic heap data structure.
 type Heap[T any] interface {
 	// Insert adds an element to the heap
@@ -17,4 +24,196 @@ type Heap[T any] interface {
 
 	// Size returns the number of elements in the heap
 	Size() int
+
+	// All returns an iterator over the elements of the heap in an arbitrary order
+	All() iter.Seq[T]
+
+	// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+	//
+	// use Sorted with slices.Collect to get the elements in priority order
+	ToSlice() []T
+
+	// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+	AppendTo(dst []T) []T
+
+	// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+	//
+	// the heap should not be modified during the iteration
+	Sorted() iter.Seq[T]
+
+	// Drain returns an iterator that removes and yields the elements of the heap in priority order
+	//
+	// the elements not reached by a stopped iteration stay in the heap
+	Drain() iter.Seq[T]
+}
+
+// HandleHeap defines the interface for a heap that, in addition to the Heap methods,
+// can change or remove elements that are already inside it through handles.
+type HandleHeap[T any] interface {
+	Heap[T]
+
+	// InsertHandle adds an element to the heap and returns a handle to it
+	InsertHandle(T) *Handle[T]
+
+	// Update replaces the element referenced by the input handle and restores the heap property
+	//
+	// returns ok = false if the handle does not reference an element of the heap
+	Update(handle *Handle[T], t T) (ok bool)
+
+	// Fix restores the heap property after the element referenced by the input handle
+	// has been changed in place
+	//
+	// returns ok = false if the handle does not reference an element of the heap
+	Fix(handle *Handle[T]) (ok bool)
+
+	// Remove removes and returns the element referenced by the input handle
+	//
+	// returns ok = false if the handle does not reference an element of the heap
+	Remove(handle *Handle[T]) (t T, ok bool)
+
+	// the heap is serialized as the sequence of its elements, decoding replaces its elements,
+	// invalidating their handles, and rebuilds the heap property with the comparator of the heap
+	json.Marshaler
+	json.Unmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// IndexedHeap defines the interface for a heap of unique keys ordered by their values,
+// which allows accessing, updating and deleting values by their keys.
+type IndexedHeap[K comparable, V any] interface {
+	// Push adds a key with the given value to the heap
+	//
+	// returns ok = false if the key is already in the heap
+	Push(key K, v V) (ok bool)
+
+	// Update replaces the value of the given key and restores the heap property
+	//
+	// returns ok = false if the key is not in the heap
+	Update(key K, v V) (ok bool)
+
+	// Delete removes the given key from the heap and returns its value
+	//
+	// returns ok = false if the key is not in the heap
+	Delete(key K) (v V, ok bool)
+
+	// Contains reports whether the given key is in the heap
+	Contains(key K) bool
+
+	// Get returns the value of the given key
+	//
+	// returns ok = false if the key is not in the heap
+	Get(key K) (v V, ok bool)
+
+	// Peek returns the root key and its value without removing them
+	//
+	// returns ok = false if heap is empty
+	Peek() (key K, v V, ok bool)
+
+	// Pop removes and returns the root key and its value
+	//
+	// returns ok = false if heap is empty
+	Pop() (key K, v V, ok bool)
+
+	// Size returns the number of keys in the heap
+	Size() int
+}
+
+// MeldableHeap defines the interface for a heap that can be efficiently merged
+// with another heap of the same implementation.
+type MeldableHeap[T any] interface {
+	Heap[T]
+
+	// Meld moves all elements of the other heap into this heap, leaving the other heap empty
+	//
+	// the comparator of this heap is used, so both heaps should be built with equivalent comparators
+	// returns ok = false if other is nil, is this same heap or is not of the same implementation
+	Meld(other MeldableHeap[T]) (ok bool)
+}
+
+// FibonacciHeap defines the interface for a Fibonacci heap, a meldable heap that
+// supports decreasing the key of an element in amortized O(1) through its node.
+type FibonacciHeap[T any] interface {
+	MeldableHeap[T]
+
+	// InsertHandle adds an element to the heap and returns its node as a handle
+	InsertHandle(T) *FibonacciNode[T]
+
+	// DecreaseKey replaces the element of the input node with an element of higher
+	// or equal priority, according to the comparator
+	//
+	// returns ok = false if the node is not an element of the heap or the new
+	// element has a lower priority than the current one
+	DecreaseKey(node *FibonacciNode[T], t T) (ok bool)
+
+	// Delete removes and returns the element of the input node
+	//
+	// returns ok = false if the node is not an element of the heap
+	Delete(node *FibonacciNode[T]) (t T, ok bool)
+}
+
+// MinMaxHeap defines the interface for a double-ended heap, which gives access
+// to both its minimum and maximum elements.
+type MinMaxHeap[T any] interface {
+	// Insert adds an element to the heap
+	Insert(T)
+
+	// PeekMin returns the minimum element without removing it
+	//
+	// returns ok = false if heap is empty
+	PeekMin() (t T, ok bool)
+
+	// PeekMax returns the maximum element without removing it
+	//
+	// returns ok = false if heap is empty
+	PeekMax() (t T, ok bool)
+
+	// ExtractMin removes and returns the minimum element
+	//
+	// returns ok = false if heap is empty
+	ExtractMin() (t T, ok bool)
+
+	// ExtractMax removes and returns the maximum element
+	//
+	// returns ok = false if heap is empty
+	ExtractMax() (t T, ok bool)
+
+	// Size returns the number of elements in the heap
+	Size() int
+
+	// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+	ToSlice() []T
+
+	// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+	AppendTo(dst []T) []T
+}
+
+// TopK defines the interface for a bounded selection of the best k elements of a stream.
+type TopK[T any] interface {
+	// Offer offers an element to the top-k, keeping it if it is among the best k elements seen so far
+	//
+	// returns ok = false if the element was rejected
+	Offer(T) (ok bool)
+
+	// Sorted returns the kept elements from the best to the worst, without changing the top-k
+	Sorted() []T
+
+	// Merge offers all elements kept by the other top-k to this top-k, leaving the other one unchanged
+	//
+	// does nothing if other is nil or is this same top-k
+	Merge(other TopK[T])
+
+	// Size returns the number of kept elements, at most k
+	Size() int
+
+	// K returns the maximum number of elements kept
+	K() int
+
+	// ToSlice returns a new slice holding the kept elements in an arbitrary order
+	ToSlice() []T
+
+	// AppendTo appends the kept elements to dst in an arbitrary order and returns the extended slice
+	AppendTo(dst []T) []T
 }
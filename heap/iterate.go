@@ -0,0 +1,98 @@
+package heap
+
+import (
+	"iter"
+	"slices"
+)
+
+// forest describes a heap-ordered forest to the iteration helpers, where every
+// node has a lower or equal priority than its parent
+//
+// the nodes are of type N, pointers for the tree based heaps and indexes for the binary heap
+type forest[N, T any] struct {
+	// roots visits the roots of the forest
+	roots func(visit func(N))
+
+	// children visits the children of the input node
+	children func(node N, visit func(N))
+
+	// value returns the element stored in the input node
+	value func(N) T
+}
+
+// all returns an iterator over the elements of the forest in an arbitrary order
+//
+// O(n)
+func (f forest[N, T]) all() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []N
+		push := func(node N) {
+			stack = append(stack, node)
+		}
+
+		f.roots(push)
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !yield(f.value(node)) {
+				return
+			}
+
+			f.children(node, push)
+		}
+	}
+}
+
+// sorted returns an iterator over the elements of the forest in priority order, without changing the forest
+//
+// the next element is always a root or a child of an already yielded node, so only those nodes
+// are kept in an auxiliary heap and the elements are computed lazily as the iteration goes
+// O(n*log(n)) to iterate all elements
+func (f forest[N, T]) sorted(less func(t1, t2 T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		frontier := &heap[N]{
+			comparator: func(n1, n2 N) bool {
+				return less(f.value(n1), f.value(n2))
+			},
+		}
+
+		f.roots(frontier.Insert)
+		for len(frontier.data) > 0 {
+			node, _ := frontier.Extract()
+			if !yield(f.value(node)) {
+				return
+			}
+
+			f.children(node, frontier.Insert)
+		}
+	}
+}
+
+// drain returns an iterator that extracts and yields the elements of the input heap
+// in priority order, until the heap is empty or the iteration is stopped
+//
+// the elements not reached by a stopped iteration stay in the heap
+func drain[T any](h Heap[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			t, ok := h.Extract()
+			if !ok || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// appendAll appends the elements of the heap to dst in the order of All and returns the extended slice
+//
+// dst grows at most once
+// O(n)
+func appendAll[T any](dst []T, h Heap[T]) []T {
+	dst = slices.Grow(dst, h.Size())
+	for t := range h.All() {
+		dst = append(dst, t)
+	}
+
+	return dst
+}
@@ -0,0 +1,345 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
+// leftistNode represents a node in a leftist or skew heap
+type leftistNode[T any] struct {
+	value T
+	left  *leftistNode[T]
+	right *leftistNode[T]
+
+	// rank is the length of the right spine of the node, only maintained by leftist heaps
+	rank int
+}
+
+// leftistHeap is an implementation of the MeldableHeap interface using either
+// a leftist heap or, when skew is true, a skew heap
+//
+// a leftist heap keeps the rank of each left child at least as large as the rank of
+// the right child, so the right spine is O(log(n)) long. A skew heap does not store
+// ranks and unconditionally swaps children while merging, which gives the same
+// bounds amortized.
+type leftistHeap[T any] struct {
+	root       *leftistNode[T]
+	size       int
+	comparator func(t1, t2 T) bool
+	skew       bool
+}
+
+// rank returns the rank of the input node, 0 for nil
+func rank[T any](node *leftistNode[T]) int {
+	if node == nil {
+		return 0
+	}
+
+	return node.rank
+}
+
+// merge merges two trees and returns the root of the merged tree
+//
+// O(log(n)), amortized for skew heaps
+func (h *leftistHeap[T]) merge(a, b *leftistNode[T]) *leftistNode[T] {
+	if h.skew {
+		return h.mergeSkew(a, b)
+	}
+
+	return h.mergeLeftist(a, b)
+}
+
+// mergeLeftist merges two leftist trees along their right spines
+//
+// the recursion depth is bounded by the length of the right spines, which is O(log(n))
+func (h *leftistHeap[T]) mergeLeftist(a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.comparator(b.value, a.value) {
+		a, b = b, a
+	}
+
+	a.right = h.mergeLeftist(a.right, b)
+	if rank(a.left) < rank(a.right) {
+		a.left, a.right = a.right, a.left
+	}
+	a.rank = rank(a.right) + 1
+
+	return a
+}
+
+// mergeSkew merges two skew trees top-down
+//
+// the right spine of a skew heap can be long, so the merge is iterative: at each step the
+// node with the higher priority keeps its old left child as its right child, and the rest
+// is merged into its left child
+func (h *leftistHeap[T]) mergeSkew(a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.comparator(b.value, a.value) {
+		a, b = b, a
+	}
+
+	root := a
+	parent := a
+	x, y := a.right, b
+	for {
+		parent.right = parent.left
+
+		if x == nil {
+			parent.left = y
+			break
+		}
+		if y == nil {
+			parent.left = x
+			break
+		}
+
+		if h.comparator(y.value, x.value) {
+			x, y = y, x
+		}
+
+		parent.left = x
+		parent = x
+		x = x.right
+	}
+
+	return root
+}
+
+// Insert adds an element to the heap
+//
+// O(log(n)), amortized for skew heaps
+func (h *leftistHeap[T]) Insert(t T) {
+	h.root = h.merge(h.root, &leftistNode[T]{value: t, rank: 1})
+	h.size += 1
+}
+
+// Extract removes and returns the root element from the heap
+//
+// returns ok = false if heap is empty
+// O(log(n)), amortized for skew heaps
+func (h *leftistHeap[T]) Extract() (t T, ok bool) {
+	if h.root == nil {
+		return
+	}
+
+	root := h.root
+	h.root = h.merge(root.left, root.right)
+	h.size -= 1
+
+	// clearing references to help garbage collection
+	root.left = nil
+	root.right = nil
+
+	return root.value, true
+}
+
+// Peek returns the root element without removing it
+//
+// returns ok = false if heap is empty
+func (h *leftistHeap[T]) Peek() (t T, ok bool) {
+	if h.root == nil {
+		return
+	}
+
+	return h.root.value, true
+}
+
+// Size returns the number of elements in the heap
+func (h *leftistHeap[T]) Size() int {
+	return h.size
+}
+
+// Meld moves all elements of the other heap into this heap, leaving the other heap empty
+//
+// returns ok = false if other is nil, is this same heap or is not of the same kind
+// (leftist heaps only meld with leftist heaps and skew heaps with skew heaps)
+// O(log(n)), amortized for skew heaps
+func (h *leftistHeap[T]) Meld(other MeldableHeap[T]) (ok bool) {
+	o, ok := other.(*leftistHeap[T])
+	if !ok || o == nil || o == h || o.skew != h.skew {
+		return false
+	}
+
+	h.root = h.merge(h.root, o.root)
+	h.size += o.size
+
+	o.root = nil
+	o.size = 0
+
+	return true
+}
+
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *leftistHeap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, h.Size()))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// O(n)
+func (h *leftistHeap[T]) AppendTo(dst []T) []T {
+	return appendAll[T](dst, h)
+}
+
+// All returns an iterator over the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *leftistHeap[T]) All() iter.Seq[T] {
+	return h.forest().all()
+}
+
+// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+//
+// the heap should not be modified during the iteration
+// O(n*log(n)) to iterate all elements, computed lazily so stopping early costs less
+func (h *leftistHeap[T]) Sorted() iter.Seq[T] {
+	return h.forest().sorted(h.comparator)
+}
+
+// Drain returns an iterator that removes and yields the elements of the heap in priority order
+//
+// the elements not reached by a stopped iteration stay in the heap
+func (h *leftistHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// forest describes the trees of the heap to the iteration helpers
+func (h *leftistHeap[T]) forest() forest[*leftistNode[T], T] {
+	return forest[*leftistNode[T], T]{
+		roots: func(visit func(*leftistNode[T])) {
+			if h.root != nil {
+				visit(h.root)
+			}
+		},
+		children: func(node *leftistNode[T], visit func(*leftistNode[T])) {
+			if node.left != nil {
+				visit(node.left)
+			}
+			if node.right != nil {
+				visit(node.right)
+			}
+		},
+		value: func(node *leftistNode[T]) T {
+			return node.value
+		},
+	}
+}
+
+// build builds the heap from the input elements by repeatedly merging pairs of trees
+//
+// O(n)
+func (h *leftistHeap[T]) build(data []T) {
+	if len(data) == 0 {
+		return
+	}
+
+	trees := make([]*leftistNode[T], len(data))
+	for i, t := range data {
+		trees[i] = &leftistNode[T]{value: t, rank: 1}
+	}
+
+	// merge the trees in rounds, halving the number of trees in each round
+	for len(trees) > 1 {
+		merged := trees[:0]
+		for i := 0; i+1 < len(trees); i += 2 {
+			merged = append(merged, h.merge(trees[i], trees[i+1]))
+		}
+		if len(trees)%2 == 1 {
+			merged = append(merged, trees[len(trees)-1])
+		}
+		trees = merged
+	}
+
+	h.root = trees[0]
+	h.size = len(data)
+}
+
+// NewLeftistHeap creates a new leftist heap with the given comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap.
+//
+// Example usage:
+// - Min-Heap: NewLeftistHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
+func NewLeftistHeap[T any](less func(t1, t2 T) bool, data ...T) (h MeldableHeap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &leftistHeap[T]{
+		comparator: less,
+	}
+	heap.build(data)
+
+	return heap
+}
+
+// NewSkewHeap creates a new skew heap with the given comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap.
+//
+// Example usage:
+// - Min-Heap: NewSkewHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
+func NewSkewHeap[T any](less func(t1, t2 T) bool, data ...T) (h MeldableHeap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &leftistHeap[T]{
+		comparator: less,
+		skew:       true,
+	}
+	heap.build(data)
+
+	return heap
+}
+
+// NewMinLeftistHeap creates a new leftist min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinLeftistHeap(3, 1, 6, 5, 2, 4)
+func NewMinLeftistHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewLeftistHeap(comparator.Less[T], data...)
+}
+
+// NewMaxLeftistHeap creates a new leftist max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxLeftistHeap(3, 1, 6, 5, 2, 4)
+func NewMaxLeftistHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewLeftistHeap(comparator.Greater[T], data...)
+}
+
+// NewMinSkewHeap creates a new skew min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinSkewHeap(3, 1, 6, 5, 2, 4)
+func NewMinSkewHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewSkewHeap(comparator.Less[T], data...)
+}
+
+// NewMaxSkewHeap creates a new skew max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxSkewHeap(3, 1, 6, 5, 2, 4)
+func NewMaxSkewHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewSkewHeap(comparator.Greater[T], data...)
+}
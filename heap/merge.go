@@ -0,0 +1,107 @@
+package heap
+
+import (
+	"github.com/TheFeij/go-collections/linkedlist"
+	"iter"
+)
+
+// cursor holds the current element of one of the merged sequences
+type cursor[T any] struct {
+	value T
+
+	// next pulls the element after value from the sequence
+	next func() (T, bool)
+
+	// index is the position of the sequence among the merged sequences, it breaks ties between equal elements
+	index int
+}
+
+// Merge returns an iterator that lazily merges the input sorted sequences into a single sorted sequence
+//
+// each input sequence should be sorted in ascending order according to less. The merge is stable, equal
+// elements are yielded in the order of their sequences in the input and their order inside each sequence.
+// The input sequences are pulled only as far as the iteration goes, and stopped when it ends
+// O(log(k)) per element for k sequences
+func Merge[T any](less func(t1, t2 T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return merge(less, false, seqs)
+}
+
+// MergeDistinct returns an iterator that lazily merges the input sorted sequences into a single
+// sorted sequence, yielding only the first of elements that are equal according to less
+//
+// elements are equal if neither of them is less than the other, equal elements are removed both
+// across sequences and inside each sequence
+// O(log(k)) per element for k sequences
+func MergeDistinct[T any](less func(t1, t2 T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return merge(less, true, seqs)
+}
+
+// MergeLists returns an iterator that lazily merges the input sorted linked lists into a single sorted sequence
+//
+// it behaves as Merge over the values of the lists, nil lists are skipped.
+// The lists should not be modified during the iteration
+// O(log(k)) per element for k lists
+func MergeLists[T any](less func(t1, t2 T) bool, lists ...linkedlist.LinkedList[T]) iter.Seq[T] {
+	seqs := make([]iter.Seq[T], 0, len(lists))
+	for _, list := range lists {
+		if list != nil {
+			seqs = append(seqs, list.Values())
+		}
+	}
+
+	return merge(less, false, seqs)
+}
+
+// merge merges the input sorted sequences using a heap of cursors, one for each non-empty sequence
+//
+// the root cursor holds the next element to yield, after yielding it the cursor is advanced and
+// pushed back down the heap, or removed if its sequence is exhausted
+func merge[T any](less func(t1, t2 T) bool, distinct bool, seqs []iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &heap[cursor[T]]{
+			data: make([]cursor[T], 0, len(seqs)),
+			comparator: func(c1, c2 cursor[T]) bool {
+				if less(c1.value, c2.value) {
+					return true
+				}
+				if less(c2.value, c1.value) {
+					return false
+				}
+
+				return c1.index < c2.index
+			},
+		}
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			if t, ok := next(); ok {
+				h.data = append(h.data, cursor[T]{value: t, next: next, index: i})
+			}
+		}
+		h.buildHeap()
+
+		var last T
+		yielded := false
+		for len(h.data) > 0 {
+			root := &h.data[0]
+
+			// the elements come in ascending order, so an element equal to the last one is not greater than it
+			if t := root.value; !distinct || !yielded || less(last, t) {
+				if !yield(t) {
+					return
+				}
+
+				last, yielded = t, true
+			}
+
+			if t, ok := root.next(); ok {
+				root.value = t
+				h.heapifyDown(0)
+			} else {
+				h.Extract()
+			}
+		}
+	}
+}
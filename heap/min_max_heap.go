@@ -0,0 +1,264 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"math/bits"
+)
+
+// minMaxHeap is an implementation of the MinMaxHeap interface
+//
+// the elements are stored in a complete binary tree, like the binary heap, where nodes
+// on even levels (starting with the root on level 0) are smaller than all their
+// descendants and nodes on odd levels are greater than all their descendants
+type minMaxHeap[T any] struct {
+	data []T
+	less func(t1, t2 T) bool
+}
+
+// isMinLevel reports whether the input index is on a min level of the tree
+func isMinLevel(index int) bool {
+	return bits.Len(uint(index+1))%2 == 1
+}
+
+// greater reports whether t1 is greater than t2
+func (h *minMaxHeap[T]) greater(t1, t2 T) bool {
+	return h.less(t2, t1)
+}
+
+// ordered returns the comparator that defines the order of the level of the input index
+func (h *minMaxHeap[T]) ordered(index int) func(t1, t2 T) bool {
+	if isMinLevel(index) {
+		return h.less
+	}
+
+	return h.greater
+}
+
+// trickleDown moves the element at the input index down until the min-max heap property holds
+//
+// O(log(n))
+func (h *minMaxHeap[T]) trickleDown(index int) {
+	before := h.ordered(index)
+	size := len(h.data)
+
+	for {
+		// find the descendant among the children and grandchildren that should come first
+		first := -1
+		firstChild := 2*index + 1
+		for _, descendant := range [...]int{
+			firstChild, firstChild + 1,
+			2*firstChild + 1, 2*firstChild + 2, 2*firstChild + 3, 2*firstChild + 4,
+		} {
+			if descendant < size && (first == -1 || before(h.data[descendant], h.data[first])) {
+				first = descendant
+			}
+		}
+
+		if first == -1 || !before(h.data[first], h.data[index]) {
+			return
+		}
+
+		h.data[first], h.data[index] = h.data[index], h.data[first]
+
+		// a child is on the other kind of level and has no descendants that can be out of order
+		if first <= firstChild+1 {
+			return
+		}
+
+		// a grandchild might now be out of order with its parent, which is on the other kind of level
+		parent := (first - 1) / 2
+		if before(h.data[parent], h.data[first]) {
+			h.data[parent], h.data[first] = h.data[first], h.data[parent]
+		}
+
+		index = first
+	}
+}
+
+// bubbleUp moves the element at the input index up until the min-max heap property holds
+//
+// O(log(n))
+func (h *minMaxHeap[T]) bubbleUp(index int) {
+	if index == 0 {
+		return
+	}
+
+	// if the element belongs to the other kind of level than its own, move it to its parent first
+	parent := (index - 1) / 2
+	if h.ordered(parent)(h.data[index], h.data[parent]) {
+		h.data[parent], h.data[index] = h.data[index], h.data[parent]
+		index = parent
+	}
+
+	// move the element up through the levels of its kind, comparing it with its grandparents
+	before := h.ordered(index)
+	for index > 2 {
+		grandparent := ((index-1)/2 - 1) / 2
+		if !before(h.data[index], h.data[grandparent]) {
+			return
+		}
+
+		h.data[grandparent], h.data[index] = h.data[index], h.data[grandparent]
+		index = grandparent
+	}
+}
+
+// buildHeap builds the min-max heap on h.data
+//
+// O(n)
+func (h *minMaxHeap[T]) buildHeap() {
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.trickleDown(i)
+	}
+}
+
+// maxIndex returns the index of the maximum element
+//
+// should not be called on an empty heap
+func (h *minMaxHeap[T]) maxIndex() int {
+	switch len(h.data) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if h.less(h.data[1], h.data[2]) {
+		return 2
+	}
+
+	return 1
+}
+
+// removeAt removes and returns the element at the input index
+//
+// does not check for the validity of the index, should be checked at the caller
+// O(log(n))
+func (h *minMaxHeap[T]) removeAt(index int) T {
+	var zero T
+
+	t := h.data[index]
+	lastIndex := len(h.data) - 1
+
+	h.data[index] = h.data[lastIndex]
+
+	// clear the reference to help garbage collection
+	h.data[lastIndex] = zero
+	h.data = h.data[:lastIndex]
+
+	if index < lastIndex {
+		h.trickleDown(index)
+	}
+
+	return t
+}
+
+// Insert adds an element to the heap
+//
+// O(log(n))
+func (h *minMaxHeap[T]) Insert(t T) {
+	h.data = append(h.data, t)
+
+	h.bubbleUp(len(h.data) - 1)
+}
+
+// PeekMin returns the minimum element without removing it
+//
+// returns ok = false if heap is empty
+// O(1)
+func (h *minMaxHeap[T]) PeekMin() (t T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+
+	return h.data[0], true
+}
+
+// PeekMax returns the maximum element without removing it
+//
+// returns ok = false if heap is empty
+// O(1)
+func (h *minMaxHeap[T]) PeekMax() (t T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+
+	return h.data[h.maxIndex()], true
+}
+
+// ExtractMin removes and returns the minimum element
+//
+// returns ok = false if heap is empty
+// O(log(n))
+func (h *minMaxHeap[T]) ExtractMin() (t T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+
+	return h.removeAt(0), true
+}
+
+// ExtractMax removes and returns the maximum element
+//
+// returns ok = false if heap is empty
+// O(log(n))
+func (h *minMaxHeap[T]) ExtractMax() (t T, ok bool) {
+	if len(h.data) == 0 {
+		return
+	}
+
+	return h.removeAt(h.maxIndex()), true
+}
+
+// Size returns the number of elements in the heap
+func (h *minMaxHeap[T]) Size() int {
+	return len(h.data)
+}
+
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *minMaxHeap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, len(h.data)))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// O(n)
+func (h *minMaxHeap[T]) AppendTo(dst []T) []T {
+	return append(dst, h.data...)
+}
+
+// NewMinMaxHeap creates a new min-max heap with the given less-than comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// less should return true if the first argument is less than the second.
+//
+// Example usage:
+// - NewMinMaxHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
+func NewMinMaxHeap[T any](less func(t1, t2 T) bool, data ...T) (h MinMaxHeap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &minMaxHeap[T]{
+		data: make([]T, len(data)),
+		less: less,
+	}
+	// copy input variadic to prevent storing reference to the input slice in the case where input is an unpacked slice
+	copy(heap.data, data)
+
+	heap.buildHeap()
+
+	return heap
+}
+
+// NewOrderedMinMaxHeap creates a new min-max heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewOrderedMinMaxHeap(3, 1, 6, 5, 2, 4)
+func NewOrderedMinMaxHeap[T cmp.Ordered](data ...T) MinMaxHeap[T] {
+	return NewMinMaxHeap(comparator.Less[T], data...)
+}
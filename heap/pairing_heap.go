@@ -0,0 +1,237 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
+// pairingNode represents a node in a pairing heap
+//
+// the children of a node are kept in a singly linked list starting at child
+// and continuing through the sibling references
+type pairingNode[T any] struct {
+	value   T
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+// pairingHeap is an implementation of the MeldableHeap interface using a pairing heap
+type pairingHeap[T any] struct {
+	root       *pairingNode[T]
+	size       int
+	comparator func(t1, t2 T) bool
+}
+
+// meld links two trees by making the root with lower priority the first child of the other root
+//
+// O(1)
+func (h *pairingHeap[T]) meld(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if h.comparator(b.value, a.value) {
+		a, b = b, a
+	}
+
+	b.sibling = a.child
+	a.child = b
+
+	return a
+}
+
+// mergePairs melds a list of sibling trees into a single tree using the two-pass method
+//
+// amortized O(log(n))
+func (h *pairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	// first pass: meld the trees in pairs from left to right, pushing each
+	// melded pair onto a stack that reuses the sibling references
+	var stack *pairingNode[T]
+	for first != nil {
+		a := first
+		b := a.sibling
+		if b == nil {
+			first = nil
+		} else {
+			first = b.sibling
+			b.sibling = nil
+		}
+		a.sibling = nil
+
+		pair := h.meld(a, b)
+		pair.sibling = stack
+		stack = pair
+	}
+
+	// second pass: meld the pairs from right to left
+	var root *pairingNode[T]
+	for stack != nil {
+		next := stack.sibling
+		stack.sibling = nil
+
+		root = h.meld(root, stack)
+		stack = next
+	}
+
+	return root
+}
+
+// Insert adds an element to the heap
+//
+// O(1)
+func (h *pairingHeap[T]) Insert(t T) {
+	h.root = h.meld(h.root, &pairingNode[T]{value: t})
+	h.size += 1
+}
+
+// Extract removes and returns the root element from the heap
+//
+// returns ok = false if heap is empty
+// amortized O(log(n))
+func (h *pairingHeap[T]) Extract() (t T, ok bool) {
+	if h.root == nil {
+		return
+	}
+
+	root := h.root
+	h.root = h.mergePairs(root.child)
+	h.size -= 1
+
+	// clearing references to help garbage collection
+	root.child = nil
+
+	return root.value, true
+}
+
+// Peek returns the root element without removing it
+//
+// returns ok = false if heap is empty
+func (h *pairingHeap[T]) Peek() (t T, ok bool) {
+	if h.root == nil {
+		return
+	}
+
+	return h.root.value, true
+}
+
+// Size returns the number of elements in the heap
+func (h *pairingHeap[T]) Size() int {
+	return h.size
+}
+
+// Meld moves all elements of the other heap into this heap, leaving the other heap empty
+//
+// returns ok = false if other is nil, is this same heap or is not a pairing heap
+// O(1)
+func (h *pairingHeap[T]) Meld(other MeldableHeap[T]) (ok bool) {
+	o, ok := other.(*pairingHeap[T])
+	if !ok || o == nil || o == h {
+		return false
+	}
+
+	h.root = h.meld(h.root, o.root)
+	h.size += o.size
+
+	o.root = nil
+	o.size = 0
+
+	return true
+}
+
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *pairingHeap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, h.Size()))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// O(n)
+func (h *pairingHeap[T]) AppendTo(dst []T) []T {
+	return appendAll[T](dst, h)
+}
+
+// All returns an iterator over the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *pairingHeap[T]) All() iter.Seq[T] {
+	return h.forest().all()
+}
+
+// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+//
+// the heap should not be modified during the iteration
+// O(n*log(n)) to iterate all elements, computed lazily so stopping early costs less
+func (h *pairingHeap[T]) Sorted() iter.Seq[T] {
+	return h.forest().sorted(h.comparator)
+}
+
+// Drain returns an iterator that removes and yields the elements of the heap in priority order
+//
+// the elements not reached by a stopped iteration stay in the heap
+func (h *pairingHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// forest describes the trees of the heap to the iteration helpers
+func (h *pairingHeap[T]) forest() forest[*pairingNode[T], T] {
+	return forest[*pairingNode[T], T]{
+		roots: func(visit func(*pairingNode[T])) {
+			if h.root != nil {
+				visit(h.root)
+			}
+		},
+		children: func(node *pairingNode[T], visit func(*pairingNode[T])) {
+			for child := node.child; child != nil; child = child.sibling {
+				visit(child)
+			}
+		},
+		value: func(node *pairingNode[T]) T {
+			return node.value
+		},
+	}
+}
+
+// NewPairingHeap creates a new pairing heap with the given comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap.
+//
+// Example usage:
+// - Min-Heap: NewPairingHeap(func(a, b int) bool { return a < b }, 3, 1, 6, 5, 2, 4)
+func NewPairingHeap[T any](less func(t1, t2 T) bool, data ...T) (h MeldableHeap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &pairingHeap[T]{
+		comparator: less,
+	}
+	for _, t := range data {
+		heap.Insert(t)
+	}
+
+	return heap
+}
+
+// NewMinPairingHeap creates a new pairing min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinPairingHeap(3, 1, 6, 5, 2, 4)
+func NewMinPairingHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewPairingHeap(comparator.Less[T], data...)
+}
+
+// NewMaxPairingHeap creates a new pairing max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxPairingHeap(3, 1, 6, 5, 2, 4)
+func NewMaxPairingHeap[T cmp.Ordered](data ...T) MeldableHeap[T] {
+	return NewPairingHeap(comparator.Greater[T], data...)
+}
@@ -0,0 +1,136 @@
+package heap
+
+import (
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
+// Sort sorts the input slice in place in ascending order according to less, using heapsort
+//
+// the sort is not stable
+// O(n*log(n)), O(1) extra memory
+func Sort[T any](s []T, less func(t1, t2 T) bool) {
+	// a max-heap keeps the largest remaining element at the root, which is moved to the end
+	h := &heap[T]{data: s, comparator: comparator.Reverse(less)}
+	h.buildHeap()
+
+	h.sortDown()
+}
+
+// PartialSort rearranges the input slice in place so that its first k elements are the
+// k smallest elements according to less, in ascending order
+//
+// the order of the remaining elements is unspecified, the whole slice is sorted if k >= len(s)
+// O(n*log(k))
+func PartialSort[T any](s []T, k int, less func(t1, t2 T) bool) {
+	if k <= 0 {
+		return
+	}
+	if k >= len(s) {
+		Sort(s, less)
+		return
+	}
+
+	h := &heap[T]{data: s[:k], comparator: comparator.Reverse(less)}
+	h.buildHeap()
+
+	// keep the k smallest elements in the max-heap, replacing its root with any smaller element
+	for i := k; i < len(s); i++ {
+		if less(s[i], s[0]) {
+			s[i], s[0] = s[0], s[i]
+			h.heapifyDown(0)
+		}
+	}
+
+	h.sortDown()
+}
+
+// Select rearranges the input slice in place so that the element at index k is the element that
+// would be there if the slice was sorted according to less, and returns it
+//
+// the elements before index k are not greater than it and the elements after it are not smaller,
+// in an unspecified order
+// returns ok = false if k is out of range
+// O(n*log(min(k, n-k)))
+func Select[T any](s []T, k int, less func(t1, t2 T) bool) (t T, ok bool) {
+	if k < 0 || k >= len(s) {
+		return
+	}
+
+	if k < len(s)/2 {
+		// keep the k+1 smallest elements in a max-heap at the front, its root is the k-th element
+		h := &heap[T]{data: s[:k+1], comparator: comparator.Reverse(less)}
+		h.buildHeap()
+
+		for i := k + 1; i < len(s); i++ {
+			if less(s[i], s[0]) {
+				s[i], s[0] = s[0], s[i]
+				h.heapifyDown(0)
+			}
+		}
+
+		s[0], s[k] = s[k], s[0]
+	} else {
+		// keep the n-k largest elements in a min-heap at the back, its root at index k is the k-th element
+		h := &heap[T]{data: s[k:], comparator: less}
+		h.buildHeap()
+
+		for i := 0; i < k; i++ {
+			if less(s[k], s[i]) {
+				s[i], s[k] = s[k], s[i]
+				h.heapifyDown(0)
+			}
+		}
+	}
+
+	return s[k], true
+}
+
+// NSmallest returns the n smallest elements of the input sequence according to less, in ascending order
+//
+// returns nil if n <= 0
+// O(m*log(n)) for a sequence of m elements, O(n) extra memory
+func NSmallest[T any](seq iter.Seq[T], n int, less func(t1, t2 T) bool) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	// a max-heap of the n smallest elements seen so far, its root is the first one to be replaced
+	h := &heap[T]{comparator: comparator.Reverse(less)}
+	for t := range seq {
+		if len(h.data) < n {
+			h.Insert(t)
+		} else if less(t, h.data[0]) {
+			h.data[0] = t
+			h.heapifyDown(0)
+		}
+	}
+
+	h.sortDown()
+
+	return h.data
+}
+
+// NLargest returns the n largest elements of the input sequence according to less, in descending order
+//
+// returns nil if n <= 0
+// O(m*log(n)) for a sequence of m elements, O(n) extra memory
+func NLargest[T any](seq iter.Seq[T], n int, less func(t1, t2 T) bool) []T {
+	return NSmallest(seq, n, comparator.Reverse(less))
+}
+
+// sortDown sorts the data of the heap in the reverse order of its comparator, by repeatedly
+// moving the root to the end of the data and restoring the heap property on the rest
+//
+// the data of the heap is restored to the full sorted slice at the end
+// O(n*log(n))
+func (h *heap[T]) sortDown() {
+	data := h.data
+	for end := len(data) - 1; end > 0; end-- {
+		h.swap(0, end)
+		h.data = data[:end]
+		h.heapifyDown(0)
+	}
+
+	h.data = data
+}
@@ -0,0 +1,159 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
+// sequenced pairs an element with the sequence number of its insertion
+type sequenced[T any] struct {
+	value    T
+	sequence uint64
+}
+
+// stableHeap is an implementation of the Heap interface that extracts
+// elements the comparator considers equal in insertion order
+type stableHeap[T any] struct {
+	heap *heap[sequenced[T]]
+
+	// sequence is the sequence number given to the next inserted element
+	sequence uint64
+}
+
+// Insert adds an element to the heap
+//
+// O(log(n))
+func (h *stableHeap[T]) Insert(t T) {
+	h.heap.Insert(sequenced[T]{value: t, sequence: h.sequence})
+	h.sequence += 1
+}
+
+// Extract removes and returns the root element from the heap,
+// among equal elements the earliest inserted one is returned first
+//
+// returns ok = false if heap is empty
+// O(log(n))
+func (h *stableHeap[T]) Extract() (t T, ok bool) {
+	element, ok := h.heap.Extract()
+	return element.value, ok
+}
+
+// Peek returns the root element without removing it
+//
+// returns ok = false if heap is empty
+func (h *stableHeap[T]) Peek() (t T, ok bool) {
+	element, ok := h.heap.Peek()
+	return element.value, ok
+}
+
+// Size returns the number of elements in the heap
+func (h *stableHeap[T]) Size() int {
+	return h.heap.Size()
+}
+
+// ToSlice returns a new slice holding the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *stableHeap[T]) ToSlice() []T {
+	return h.AppendTo(make([]T, 0, h.Size()))
+}
+
+// AppendTo appends the elements of the heap to dst in an arbitrary order and returns the extended slice
+//
+// O(n)
+func (h *stableHeap[T]) AppendTo(dst []T) []T {
+	return appendAll[T](dst, h)
+}
+
+// All returns an iterator over the elements of the heap in an arbitrary order
+//
+// O(n)
+func (h *stableHeap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range h.heap.All() {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over the elements of the heap in priority order, without removing them
+//
+// the heap should not be modified during the iteration
+// O(k*log(k)) to iterate the first k elements
+func (h *stableHeap[T]) Sorted() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range h.heap.Sorted() {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Drain returns an iterator that removes and yields the elements of the heap in priority order
+//
+// the elements not reached by a stopped iteration stay in the heap
+func (h *stableHeap[T]) Drain() iter.Seq[T] {
+	return drain[T](h)
+}
+
+// NewStableHeap creates a new stable heap with the given comparator and optional initial elements
+//
+// # Returns nil if less is nil
+//
+// The less function defines the heap property the same way as in NewHeap.
+// Ties between elements that less considers equal are broken by insertion order,
+// so equal elements are extracted first-in, first-out. The initial elements are
+// considered inserted in the order they are given.
+//
+// Example usage:
+// - Min-Heap: NewStableHeap(func(a, b Job) bool { return a.Priority < b.Priority })
+func NewStableHeap[T any](less func(t1, t2 T) bool, data ...T) (h Heap[T]) {
+	if less == nil {
+		return
+	}
+
+	heap := &stableHeap[T]{
+		heap: &heap[sequenced[T]]{
+			data: make([]sequenced[T], len(data)),
+			comparator: func(t1, t2 sequenced[T]) bool {
+				if less(t1.value, t2.value) {
+					return true
+				}
+				if less(t2.value, t1.value) {
+					return false
+				}
+
+				return t1.sequence < t2.sequence
+			},
+		},
+	}
+
+	for i, t := range data {
+		heap.heap.data[i] = sequenced[T]{value: t, sequence: uint64(i)}
+	}
+	heap.sequence = uint64(len(data))
+
+	heap.heap.buildHeap()
+
+	return heap
+}
+
+// NewMinStableHeap creates a new stable min-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMinStableHeap(3, 1, 6, 5, 2, 4)
+func NewMinStableHeap[T cmp.Ordered](data ...T) Heap[T] {
+	return NewStableHeap(comparator.Less[T], data...)
+}
+
+// NewMaxStableHeap creates a new stable max-heap of an ordered type with optional initial elements
+//
+// Example usage:
+// - NewMaxStableHeap(3, 1, 6, 5, 2, 4)
+func NewMaxStableHeap[T cmp.Ordered](data ...T) Heap[T] {
+	return NewStableHeap(comparator.Greater[T], data...)
+}
@@ -0,0 +1,170 @@
+package heap
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+	"slices"
+)
+
+// topK is an implementation of the TopK interface
+//
+// it keeps the best k elements in a heap ordered by the reversed comparator,
+// so the root is the worst kept element and the first one to be replaced
+type topK[T any] struct {
+	heap       *heap[T]
+	k          int
+	comparator func(t1, t2 T) bool
+}
+
+// Offer offers an element to the top-k, keeping it if it is among the best k elements seen so far
+//
+// returns ok = false if the element was rejected
+// O(1) if the element is rejected, O(log(k)) otherwise
+func (tk *topK[T]) Offer(t T) (ok bool) {
+	if len(tk.heap.data) < tk.k {
+		tk.heap.Insert(t)
+		return true
+	}
+
+	// a non-competitive element is rejected by comparing it with the worst kept element
+	if !tk.comparator(t, tk.heap.data[0]) {
+		return false
+	}
+
+	tk.heap.data[0] = t
+	tk.heap.heapifyDown(0)
+
+	return true
+}
+
+// Sorted returns the kept elements from the best to the worst, without changing the top-k
+//
+// O(k log(k))
+func (tk *topK[T]) Sorted() []T {
+	sorted := slices.Clone(tk.heap.data)
+	slices.SortFunc(sorted, func(t1, t2 T) int {
+		if tk.comparator(t1, t2) {
+			return -1
+		}
+		if tk.comparator(t2, t1) {
+			return 1
+		}
+		return 0
+	})
+
+	return sorted
+}
+
+// Merge offers all elements kept by the other top-k to this top-k, leaving the other one unchanged
+//
+// does nothing if other is nil or is this same top-k, which already keeps its own elements
+// O(k log(k))
+func (tk *topK[T]) Merge(other TopK[T]) {
+	if other == nil {
+		return
+	}
+	if o, ok := other.(*topK[T]); ok && (o == nil || o == tk) {
+		return
+	}
+
+	for _, t := range other.Sorted() {
+		if !tk.Offer(t) {
+			// the elements come from the best to the worst, so the rest are rejected too
+			return
+		}
+	}
+}
+
+// fill offers the elements of the input sequence to an empty top-k
+//
+// the first k elements are heapified at once and the rest are offered one by one
+// O(k + m log(k)) for m elements
+func (tk *topK[T]) fill(seq iter.Seq[T]) {
+	for t := range seq {
+		if len(tk.heap.data) < tk.k {
+			tk.heap.data = append(tk.heap.data, t)
+			if len(tk.heap.data) == tk.k {
+				tk.heap.buildHeap()
+			}
+			continue
+		}
+
+		tk.Offer(t)
+	}
+
+	if len(tk.heap.data) < tk.k {
+		tk.heap.buildHeap()
+	}
+}
+
+// Size returns the number of kept elements, at most k
+func (tk *topK[T]) Size() int {
+	return len(tk.heap.data)
+}
+
+// K returns the maximum number of elements kept
+func (tk *topK[T]) K() int {
+	return tk.k
+}
+
+// ToSlice returns a new slice holding the kept elements in an arbitrary order
+//
+// O(k)
+func (tk *topK[T]) ToSlice() []T {
+	return tk.AppendTo(make([]T, 0, len(tk.heap.data)))
+}
+
+// AppendTo appends the kept elements to dst in an arbitrary order and returns the extended slice
+//
+// O(k)
+func (tk *topK[T]) AppendTo(dst []T) []T {
+	return append(dst, tk.heap.data...)
+}
+
+// NewTopK creates a new top-k that keeps the best k elements according to the given comparator
+//
+// # Returns nil if less is nil or k is not positive
+//
+// less should return true if the first argument is better than the second:
+// - For the k largest elements, less should return true if the first argument is greater than the second
+// - For the k smallest elements, less should return true if the first argument is less than the second
+//
+// Example usage:
+// - 10 largest: NewTopK(10, func(a, b int) bool { return a > b })
+func NewTopK[T any](k int, less func(t1, t2 T) bool) (tk TopK[T]) {
+	if less == nil || k <= 0 {
+		return
+	}
+
+	return &topK[T]{
+		heap: &heap[T]{
+			data: make([]T, 0, k),
+			comparator: func(t1, t2 T) bool {
+				return less(t2, t1)
+			},
+		},
+		k:          k,
+		comparator: less,
+	}
+}
+
+// NewTopKLargest creates a new top-k that keeps the k largest elements of an ordered type
+//
+// # Returns nil if k is not positive
+//
+// Example usage:
+// - NewTopKLargest[int](10)
+func NewTopKLargest[T cmp.Ordered](k int) TopK[T] {
+	return NewTopK(k, comparator.Greater[T])
+}
+
+// NewTopKSmallest creates a new top-k that keeps the k smallest elements of an ordered type
+//
+// # Returns nil if k is not positive
+//
+// Example usage:
+// - NewTopKSmallest[int](10)
+func NewTopKSmallest[T cmp.Ordered](k int) TopK[T] {
+	return NewTopK(k, comparator.Less[T])
+}
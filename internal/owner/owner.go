@@ -0,0 +1,40 @@
+// Package owner holds the ownership tracking shared by the collections whose nodes are
+// handed out as handles, such as the elements of a doubly linked list and the nodes of
+// a Fibonacci heap, to check in O(1) whether a handle belongs to a collection.
+package owner
+
+// Owner identifies a collection for the nodes inside it
+//
+// when all nodes of a collection move into another collection, the owner of the first
+// one is linked to the owner of the other one instead of updating every node, so the owners
+// form a disjoint-set forest and a node belongs to the collection whose owner is the root
+// of the node's owner
+type Owner struct {
+	parent *Owner
+}
+
+// Find returns the root owner of the owner, compressing the path to it
+//
+// amortized almost O(1)
+func (o *Owner) Find() *Owner {
+	root := o
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	for o != root {
+		next := o.parent
+		o.parent = root
+		o = next
+	}
+
+	return root
+}
+
+// LinkTo links the owner to the input root owner, so the nodes owned by o become owned by root
+//
+// o and root should both be root owners
+// O(1)
+func (o *Owner) LinkTo(root *Owner) {
+	o.parent = root
+}
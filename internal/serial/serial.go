@@ -0,0 +1,51 @@
+// Package serial holds the encoding helpers shared by the collections, which are all
+// serialized as the sequence of their elements.
+package serial
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// MarshalJSON encodes the values as a JSON array, an empty array if there are no values
+func MarshalJSON[T any](values []T) ([]byte, error) {
+	if values == nil {
+		values = []T{}
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes a JSON array into a new slice
+//
+// values is nil only for a JSON null, which by convention should leave the collection unchanged,
+// an empty array gives an empty non-nil slice
+func UnmarshalJSON[T any](data []byte) (values []T, err error) {
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// EncodeGob encodes the values as a gob stream holding a single slice
+func EncodeGob[T any](values []T) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(values); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// DecodeGob decodes a gob stream written by EncodeGob into a new slice
+//
+// gob does not distinguish empty slices from nil ones, so no values give a nil slice
+func DecodeGob[T any](data []byte) (values []T, err error) {
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -1,17 +1,75 @@
 package linkedlist
 
-// doublyNode represents a node in a doubly linked list
-type doublyNode[T any] struct {
+import (
+	"github.com/TheFeij/go-collections/comparator"
+	"github.com/TheFeij/go-collections/internal/owner"
+	"iter"
+	"slices"
+)
+
+// Element represents a node in a doubly linked list
+//
+// it is returned by the doubly linked list as a handle to its elements and
+// can be used to insert, remove or move elements in O(1)
+type Element[T any] struct {
 	value    T
-	next     *doublyNode[T]
-	previous *doublyNode[T]
+	next     *Element[T]
+	previous *Element[T]
+
+	// owner identifies the linked list that the element belongs to,
+	// nil if the element has been removed from its list
+	owner *owner.Owner
+}
+
+// Value returns the value stored in the element
+func (e *Element[T]) Value() T {
+	return e.value
+}
+
+// SetValue replaces the value stored in the element
+func (e *Element[T]) SetValue(t T) {
+	e.value = t
+}
+
+// Next returns the next element of the linked list
+//
+// returns nil if e is the last element or has been removed from its list
+func (e *Element[T]) Next() *Element[T] {
+	if e.owner == nil {
+		return nil
+	}
+
+	return e.next
+}
+
+// Prev returns the previous element of the linked list
+//
+// returns nil if e is the first element or has been removed from its list
+func (e *Element[T]) Prev() *Element[T] {
+	if e.owner == nil {
+		return nil
+	}
+
+	return e.previous
 }
 
 // doublyLinkedList is an implementation of the LinkedList interface
 type doublyLinkedList[T any] struct {
-	first *doublyNode[T]
-	last  *doublyNode[T]
+	first *Element[T]
+	last  *Element[T]
 	size  int
+
+	// owner is the owner of the elements of the linked list, always a root owner
+	//
+	// it stays nil until the linked list gets its first element
+	owner *owner.Owner
+
+	// modCount counts the structural modifications of the linked list,
+	// used by iterators to detect modifications during iteration
+	modCount int
+
+	// equal compares values for the search methods, set by the constructor
+	equal func(t1, t2 T) bool
 }
 
 // InsertToIndex inserts input value to the given index
@@ -30,20 +88,8 @@ func (l *doublyLinkedList[T]) InsertToIndex(t T, index int) (ok bool) {
 	// after the above conditions are passed, we are sure that the index we are
 	// about to add has both next and previous elements
 
-	// get the element at the input index
-	currNodeAtIndex := l.get(index)
-
-	newNodeAtIndex := &doublyNode[T]{
-		value:    t,
-		previous: currNodeAtIndex.previous,
-		next:     currNodeAtIndex,
-	}
-
-	currNodeAtIndex.previous.next = newNodeAtIndex
-	currNodeAtIndex.previous = newNodeAtIndex
-
-	// update the size
-	l.size += 1
+	// get the element at the input index and insert the new element before it
+	l.insertBefore(t, l.get(index))
 
 	return true
 }
@@ -77,10 +123,12 @@ func (l *doublyLinkedList[T]) DeleteIndex(index int) (ok bool) {
 
 	// update the size
 	l.size -= 1
+	l.modCount += 1
 
 	// dereference the node to help with garbage collection
 	currNodeAtIndex.next = nil
 	currNodeAtIndex.previous = nil
+	currNodeAtIndex.owner = nil
 	currNodeAtIndex = nil
 
 	return true
@@ -107,11 +155,11 @@ func (l *doublyLinkedList[T]) Get(index int) (t T, ok bool) {
 //
 // does not check for the validity of the index, should be checked at the caller
 // worst case O(n/2)
-func (l *doublyLinkedList[T]) get(index int) *doublyNode[T] {
+func (l *doublyLinkedList[T]) get(index int) *Element[T] {
 	distanceToLast := l.size - index
 	distanceToFirst := index
 
-	var currNode *doublyNode[T]
+	var currNode *Element[T]
 	if distanceToFirst < distanceToLast {
 		currNode = l.first
 		for i := 0; i < distanceToFirst; i++ {
@@ -140,12 +188,14 @@ func (l *doublyLinkedList[T]) Clear() {
 		next := current.next
 		current.next = nil
 		current.previous = nil
+		current.owner = nil
 		current = next
 	}
 
 	l.first = nil
 	l.last = nil
 	l.size = 0
+	l.modCount += 1
 }
 
 // GetFirst returns the first element of the linked list
@@ -177,10 +227,21 @@ func (l *doublyLinkedList[T]) Add(t T) {
 
 // AddFirst adds input value to the start of the linked list
 func (l *doublyLinkedList[T]) AddFirst(t T) {
-	newNode := &doublyNode[T]{
+	l.PushFront(t)
+}
+
+// AddLast adds input value to the end of the linked list
+func (l *doublyLinkedList[T]) AddLast(t T) {
+	l.PushBack(t)
+}
+
+// PushFront adds input value to the start of the linked list and returns its element
+func (l *doublyLinkedList[T]) PushFront(t T) *Element[T] {
+	newNode := &Element[T]{
 		value:    t,
 		next:     nil,
 		previous: nil,
+		owner:    l.root(),
 	}
 
 	if l.size == 0 {
@@ -192,14 +253,18 @@ func (l *doublyLinkedList[T]) AddFirst(t T) {
 
 	l.first = newNode
 	l.size += 1
+	l.modCount += 1
+
+	return newNode
 }
 
-// AddLast adds input value to the end of the linked list
-func (l *doublyLinkedList[T]) AddLast(t T) {
-	newNode := &doublyNode[T]{
+// PushBack adds input value to the end of the linked list and returns its element
+func (l *doublyLinkedList[T]) PushBack(t T) *Element[T] {
+	newNode := &Element[T]{
 		value:    t,
 		next:     nil,
 		previous: nil,
+		owner:    l.root(),
 	}
 
 	if l.size == 0 {
@@ -211,6 +276,9 @@ func (l *doublyLinkedList[T]) AddLast(t T) {
 
 	l.last = newNode
 	l.size += 1
+	l.modCount += 1
+
+	return newNode
 }
 
 // DeleteFirst deletes first element of the linked list
@@ -247,9 +315,11 @@ func (l *doublyLinkedList[T]) deleteFirst() {
 	// clearing references to help garbage collection
 	first.next = nil
 	first.previous = nil
+	first.owner = nil
 	first = nil
 
 	l.size -= 1
+	l.modCount += 1
 }
 
 // DeleteLast deletes last element of the linked list
@@ -286,16 +356,720 @@ func (l *doublyLinkedList[T]) deleteLast() {
 	// clearing references to help garbage collection
 	last.next = nil
 	last.previous = nil
+	last.owner = nil
 	last = nil
 
 	l.size -= 1
+	l.modCount += 1
+}
+
+// Front returns the first element of the linked list
+//
+// returns nil if the linked list is empty
+func (l *doublyLinkedList[T]) Front() *Element[T] {
+	return l.first
+}
+
+// Back returns the last element of the linked list
+//
+// returns nil if the linked list is empty
+func (l *doublyLinkedList[T]) Back() *Element[T] {
+	return l.last
+}
+
+// InsertBefore inserts input value right before the mark element and returns the new element
+//
+// returns nil if mark is not an element of the linked list
+// O(1)
+func (l *doublyLinkedList[T]) InsertBefore(t T, mark *Element[T]) *Element[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+
+	return l.insertBefore(t, mark)
+}
+
+// insertBefore inserts input value right before the mark element and returns the new element
+//
+// does not check if mark belongs to the linked list, should be checked at the caller
+func (l *doublyLinkedList[T]) insertBefore(t T, mark *Element[T]) *Element[T] {
+	if mark == l.first {
+		return l.PushFront(t)
+	}
+
+	// after the above condition, we are sure that mark has a previous element
+	newNode := &Element[T]{
+		value:    t,
+		previous: mark.previous,
+		next:     mark,
+		owner:    l.root(),
+	}
+
+	mark.previous.next = newNode
+	mark.previous = newNode
+
+	// update the size
+	l.size += 1
+	l.modCount += 1
+
+	return newNode
+}
+
+// InsertAfter inserts input value right after the mark element and returns the new element
+//
+// returns nil if mark is not an element of the linked list
+// O(1)
+func (l *doublyLinkedList[T]) InsertAfter(t T, mark *Element[T]) *Element[T] {
+	if !l.owns(mark) {
+		return nil
+	}
+
+	if mark == l.last {
+		return l.PushBack(t)
+	}
+
+	// after the above condition, we are sure that mark has a next element
+	newNode := &Element[T]{
+		value:    t,
+		previous: mark,
+		next:     mark.next,
+		owner:    l.root(),
+	}
+
+	mark.next.previous = newNode
+	mark.next = newNode
+
+	// update the size
+	l.size += 1
+	l.modCount += 1
+
+	return newNode
+}
+
+// Remove removes the input element from the linked list
+//
+// ok = false means e is not an element of the linked list
+// O(1)
+func (l *doublyLinkedList[T]) Remove(e *Element[T]) (ok bool) {
+	if !l.owns(e) {
+		return
+	}
+
+	l.unlink(e)
+
+	// clearing references to help garbage collection
+	e.next = nil
+	e.previous = nil
+	e.owner = nil
+
+	l.size -= 1
+	l.modCount += 1
+
+	return true
+}
+
+// MoveToFront moves the input element to the start of the linked list
+//
+// ok = false means e is not an element of the linked list
+// O(1)
+func (l *doublyLinkedList[T]) MoveToFront(e *Element[T]) (ok bool) {
+	if !l.owns(e) {
+		return
+	}
+
+	if e == l.first {
+		return true
+	}
+
+	// after the above condition, the list has at least one other element
+	// which will remain in the list after unlinking e
+	l.unlink(e)
+
+	e.previous = nil
+	e.next = l.first
+	l.first.previous = e
+	l.first = e
+
+	l.modCount += 1
+
+	return true
+}
+
+// MoveToBack moves the input element to the end of the linked list
+//
+// ok = false means e is not an element of the linked list
+// O(1)
+func (l *doublyLinkedList[T]) MoveToBack(e *Element[T]) (ok bool) {
+	if !l.owns(e) {
+		return
+	}
+
+	if e == l.last {
+		return true
+	}
+
+	// after the above condition, the list has at least one other element
+	// which will remain in the list after unlinking e
+	l.unlink(e)
+
+	e.next = nil
+	e.previous = l.last
+	l.last.next = e
+	l.last = e
+
+	l.modCount += 1
+
+	return true
+}
+
+// root returns the owner of the elements of the linked list, creating it on first use
+func (l *doublyLinkedList[T]) root() *owner.Owner {
+	if l.owner == nil {
+		l.owner = &owner.Owner{}
+	}
+
+	return l.owner
+}
+
+// owns reports whether the input element belongs to the linked list
+func (l *doublyLinkedList[T]) owns(e *Element[T]) bool {
+	return e != nil && e.owner != nil && e.owner.Find() == l.owner
+}
+
+// unlink detaches the input element from its neighbours and updates
+// the first and last elements of the linked list if needed
+//
+// does not update the size or the element's own references, should be done at the caller
+func (l *doublyLinkedList[T]) unlink(e *Element[T]) {
+	if e.previous == nil {
+		l.first = e.next
+	} else {
+		e.previous.next = e.next
+	}
+
+	if e.next == nil {
+		l.last = e.previous
+	} else {
+		e.next.previous = e.previous
+	}
+}
+
+// RemoveIf removes all elements of the linked list that satisfy the input predicate
+// and returns the number of removed elements
+//
+// O(n)
+func (l *doublyLinkedList[T]) RemoveIf(predicate func(T) bool) (removed int) {
+	currNode := l.first
+	for currNode != nil {
+		next := currNode.next
+
+		if predicate(currNode.value) {
+			l.unlink(currNode)
+
+			// clearing references to help garbage collection
+			currNode.next = nil
+			currNode.previous = nil
+			currNode.owner = nil
+
+			removed++
+		}
+
+		currNode = next
+	}
+
+	if removed == 0 {
+		return
+	}
+
+	l.size -= removed
+	l.modCount += 1
+
+	return
+}
+
+// IndexOf returns the index of the first element equal to the input value
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func (l *doublyLinkedList[T]) IndexOf(t T) (index int, ok bool) {
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if l.equal(currNode.value, t) {
+			return index, true
+		}
+
+		index++
+	}
+
+	return 0, false
+}
+
+// LastIndexOf returns the index of the last element equal to the input value
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func (l *doublyLinkedList[T]) LastIndexOf(t T) (index int, ok bool) {
+	index = l.size - 1
+	for currNode := l.last; currNode != nil; currNode = currNode.previous {
+		if l.equal(currNode.value, t) {
+			return index, true
+		}
+
+		index--
+	}
+
+	return 0, false
+}
+
+// Contains reports whether the linked list has an element equal to the input value
+//
+// O(n)
+func (l *doublyLinkedList[T]) Contains(t T) bool {
+	_, ok := l.IndexOf(t)
+	return ok
+}
+
+// Find returns the first value of the linked list that satisfies the input predicate
+//
+// ok = false means no value satisfies the predicate
+// O(n)
+func (l *doublyLinkedList[T]) Find(predicate func(T) bool) (t T, ok bool) {
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if predicate(currNode.value) {
+			return currNode.value, true
+		}
+	}
+
+	return
+}
+
+// RemoveFirstMatch removes the first element equal to the input value
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func (l *doublyLinkedList[T]) RemoveFirstMatch(t T) (ok bool) {
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if l.equal(currNode.value, t) {
+			return l.Remove(currNode)
+		}
+	}
+
+	return
+}
+
+// RemoveAll removes all elements equal to the input value and returns the number of removed elements
+//
+// O(n)
+func (l *doublyLinkedList[T]) RemoveAll(t T) (removed int) {
+	return l.RemoveIf(func(value T) bool {
+		return l.equal(value, t)
+	})
+}
+
+// Sort sorts the linked list in place according to less
+//
+// the sort is stable and relinks the elements with a bottom-up merge sort, without allocating.
+// The elements stay valid and keep their values
+// O(n*log(n))
+func (l *doublyLinkedList[T]) Sort(less comparator.Func[T]) {
+	if l.size < 2 {
+		return
+	}
+
+	// merge sorted runs of width elements in pairs, doubling the width on each pass
+	for width := 1; width < l.size; width *= 2 {
+		var first, last *Element[T]
+
+		rest := l.first
+		for rest != nil {
+			a := rest
+			b := splitDoubly(a, width)
+			rest = splitDoubly(b, width)
+
+			mergedFirst, mergedLast := mergeDoubly(a, b, less)
+			if last == nil {
+				first = mergedFirst
+			} else {
+				last.next = mergedFirst
+				mergedFirst.previous = last
+			}
+			last = mergedLast
+		}
+
+		l.first = first
+		l.last = last
+	}
+
+	l.modCount += 1
+}
+
+// Reverse reverses the order of the elements of the linked list in place
+//
+// O(n)
+func (l *doublyLinkedList[T]) Reverse() {
+	if l.size < 2 {
+		return
+	}
+
+	for currNode := l.first; currNode != nil; currNode = currNode.previous {
+		currNode.next, currNode.previous = currNode.previous, currNode.next
+	}
+	l.first, l.last = l.last, l.first
+
+	l.modCount += 1
+}
+
+// MergeSorted merges the elements of the other sorted linked list into this sorted linked list
+// according to less, leaving the other linked list empty
+//
+// the merge is stable, on ties the elements of this linked list come first.
+// The elements of the other linked list stay valid and become elements of this linked list
+// ok = false means other is nil, is this same linked list or is not a doubly linked list
+// O(n+m)
+func (l *doublyLinkedList[T]) MergeSorted(other LinkedList[T], less comparator.Func[T]) (ok bool) {
+	o, isDoubly := other.(*doublyLinkedList[T])
+	if !isDoubly || o == nil || o == l {
+		return
+	}
+
+	if o.size == 0 {
+		return true
+	}
+
+	first, _, size := l.take(o)
+
+	l.first, l.last = mergeDoubly(l.first, first, less)
+	l.size += size
+	l.modCount += 1
+
+	return true
+}
+
+// Concat moves all elements of the other linked list to the end of this linked list,
+// leaving the other linked list empty
+//
+// the elements of the other linked list stay valid and become elements of this linked list
+// ok = false means other is nil, is this same linked list or is not a doubly linked list
+// O(1)
+func (l *doublyLinkedList[T]) Concat(other LinkedList[T]) (ok bool) {
+	return l.SpliceAt(l.size, other)
+}
+
+// SpliceAt moves all elements of the other linked list into this linked list, so that the first
+// of them is at the input index, leaving the other linked list empty
+//
+// index can be Size(), which moves the elements to the end of this linked list.
+// The elements of the other linked list stay valid and become elements of this linked list
+// ok = false means the index is out of range, or other is nil, is this same linked list or is
+// not a doubly linked list
+// O(min(index, n-index))
+func (l *doublyLinkedList[T]) SpliceAt(index int, other LinkedList[T]) (ok bool) {
+	o, isDoubly := other.(*doublyLinkedList[T])
+	if !isDoubly || o == nil || o == l || index < 0 || index > l.size {
+		return
+	}
+
+	if o.size == 0 {
+		return true
+	}
+
+	first, last, size := l.take(o)
+	l.insertChain(index, first, last, size)
+
+	return true
+}
+
+// SplitAt moves the elements from the input index to the end of the linked list into a new
+// doubly linked list and returns it
+//
+// index can be Size(), which returns an empty linked list.
+// The moved elements stay valid and become elements of the new linked list
+// returns nil if the index is out of range
+// O(min(index, n-index))
+func (l *doublyLinkedList[T]) SplitAt(index int) LinkedList[T] {
+	if index < 0 || index > l.size {
+		return nil
+	}
+
+	split := &doublyLinkedList[T]{equal: l.equal}
+	if index == l.size {
+		return split
+	}
+
+	size := l.size - index
+	first, last := l.cut(index, l.size)
+
+	// only the elements of the shorter part get a new owner, if fewer elements stay in this
+	// linked list, the new linked list takes over the current owner instead
+	if index < size {
+		split.owner, l.owner = l.owner, split.owner
+		for e := l.first; e != nil; e = e.next {
+			e.owner = l.root()
+		}
+	} else {
+		for e := first; e != nil; e = e.next {
+			e.owner = split.root()
+		}
+	}
+
+	split.insertChain(0, first, last, size)
+
+	return split
+}
+
+// MoveRange moves the elements in the index range [from, to) of the linked list to the end of dst
+//
+// the moved elements stay valid and become elements of dst
+// ok = false means the range is out of range or reversed, or dst is nil, is this same
+// linked list or is not a doubly linked list
+// O(min(from, n-from) + to-from)
+func (l *doublyLinkedList[T]) MoveRange(from, to int, dst LinkedList[T]) (ok bool) {
+	d, isDoubly := dst.(*doublyLinkedList[T])
+	if !isDoubly || d == nil || d == l || from < 0 || to > l.size || from > to {
+		return
+	}
+
+	if from == to {
+		return true
+	}
+
+	first, last := l.cut(from, to)
+	for e := first; e != nil; e = e.next {
+		e.owner = d.root()
+	}
+
+	d.insertChain(d.size, first, last, to-from)
+
+	return true
+}
+
+// take empties the other linked list and returns its chain of elements, which become elements of l
+//
+// the elements are not updated one by one, instead the owner of the other linked list is
+// linked to the owner of l and the other linked list gets a new owner on its next element
+// O(1)
+func (l *doublyLinkedList[T]) take(o *doublyLinkedList[T]) (first, last *Element[T], size int) {
+	first, last, size = o.first, o.last, o.size
+
+	o.owner.LinkTo(l.root())
+	o.owner = nil
+
+	o.first = nil
+	o.last = nil
+	o.size = 0
+	o.modCount += 1
+
+	return first, last, size
+}
+
+// cut detaches the elements in the index range [from, to) from the linked list and returns
+// the first and last of them, the range should be valid and not empty
+//
+// does not change the owner of the detached elements, should be done at the caller
+// O(min(from, n-from) + to-from), the second term is dropped if to is the size of the linked list
+func (l *doublyLinkedList[T]) cut(from, to int) (first, last *Element[T]) {
+	first = l.get(from)
+
+	if to == l.size {
+		last = l.last
+	} else {
+		last = first
+		for i := from + 1; i < to; i++ {
+			last = last.next
+		}
+	}
+
+	previous, next := first.previous, last.next
+	first.previous = nil
+	last.next = nil
+
+	if previous == nil {
+		l.first = next
+	} else {
+		previous.next = next
+	}
+
+	if next == nil {
+		l.last = previous
+	} else {
+		next.previous = previous
+	}
+
+	l.size -= to - from
+	l.modCount += 1
+
+	return first, last
+}
+
+// insertChain links a chain of size elements into the linked list, so that its first element
+// is at the input index, the index should be in range [0, size of the linked list]
+//
+// does not change the owner of the inserted elements, should be done at the caller
+// O(min(index, n-index))
+func (l *doublyLinkedList[T]) insertChain(index int, first, last *Element[T], size int) {
+	var previous, next *Element[T]
+	if index == l.size {
+		previous = l.last
+	} else {
+		next = l.get(index)
+		previous = next.previous
+	}
+
+	first.previous = previous
+	last.next = next
+
+	if previous == nil {
+		l.first = first
+	} else {
+		previous.next = first
+	}
+
+	if next == nil {
+		l.last = last
+	} else {
+		next.previous = last
+	}
+
+	l.size += size
+	l.modCount += 1
+}
+
+// splitDoubly cuts the chain of elements starting at the input element after n elements
+// and returns the first element of the rest of the chain, nil if there is none
+func splitDoubly[T any](e *Element[T], n int) *Element[T] {
+	for i := 1; e != nil && i < n; i++ {
+		e = e.next
+	}
+
+	if e == nil {
+		return nil
+	}
+
+	rest := e.next
+	e.next = nil
+	if rest != nil {
+		rest.previous = nil
+	}
+
+	return rest
+}
+
+// mergeDoubly merges two sorted chains of elements and returns the first and last elements
+// of the merged chain, with their previous references linked
+//
+// on ties the elements of a come first, returns nil elements if both chains are empty
+func mergeDoubly[T any](a, b *Element[T], less comparator.Func[T]) (first, last *Element[T]) {
+	// the chains are linked without a dummy head element, which would escape to the heap
+	for a != nil || b != nil {
+		var e *Element[T]
+		if a == nil || (b != nil && less(b.value, a.value)) {
+			e = b
+			b = b.next
+		} else {
+			e = a
+			a = a.next
+		}
+
+		if last == nil {
+			first = e
+		} else {
+			last.next = e
+		}
+		e.previous = last
+		last = e
+	}
+
+	return first, last
+}
+
+// All returns an iterator over the index-value pairs of the linked list,
+// from the first element to the last
+//
+// O(n)
+func (l *doublyLinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		modCount := l.modCount
+
+		index := 0
+		for currNode := l.first; currNode != nil; currNode = currNode.next {
+			if !yield(index, currNode.value) {
+				return
+			}
+			checkModification(modCount, l.modCount)
+			index++
+		}
+	}
+}
+
+// Values returns an iterator over the values of the linked list,
+// from the first element to the last
+//
+// O(n)
+func (l *doublyLinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		modCount := l.modCount
+
+		for currNode := l.first; currNode != nil; currNode = currNode.next {
+			if !yield(currNode.value) {
+				return
+			}
+			checkModification(modCount, l.modCount)
+		}
+	}
+}
+
+// ToSlice returns a new slice holding the values of the linked list,
+// from the first element to the last
+//
+// O(n)
+func (l *doublyLinkedList[T]) ToSlice() []T {
+	return l.AppendTo(make([]T, 0, l.size))
+}
+
+// AppendTo appends the values of the linked list to dst, from the first element
+// to the last, and returns the extended slice
+//
+// dst grows at most once
+// O(n)
+func (l *doublyLinkedList[T]) AppendTo(dst []T) []T {
+	dst = slices.Grow(dst, l.size)
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		dst = append(dst, currNode.value)
+	}
+
+	return dst
+}
+
+// Backward returns an iterator over the index-value pairs of the linked list,
+// from the last element to the first
+//
+// O(n)
+func (l *doublyLinkedList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		modCount := l.modCount
+
+		index := l.size - 1
+		for currNode := l.last; currNode != nil; currNode = currNode.previous {
+			if !yield(index, currNode.value) {
+				return
+			}
+			checkModification(modCount, l.modCount)
+			index--
+		}
+	}
 }
 
 // NewDoublyLinkedList returns a new doubly linked list
-func NewDoublyLinkedList[T any]() LinkedList[T] {
+//
+// Example usage:
+// - NewDoublyLinkedList[int]()
+// - NewDoublyLinkedList(WithEquality(func(a, b User) bool { return a.ID == b.ID }))
+func NewDoublyLinkedList[T any](opts ...Option[T]) DoublyLinkedList[T] {
+	o := newOptions(opts)
+
 	return &doublyLinkedList[T]{
 		first: nil,
 		last:  nil,
 		size:  0,
+		equal: o.equal,
 	}
 }
@@ -0,0 +1,119 @@
+package linkedlist
+
+import "github.com/TheFeij/go-collections/internal/serial"
+
+// The linked lists are serialized as the sequence of their values, from the first element to the last.
+// Decoding replaces the values of the linked list and keeps its equality, so a linked list
+// built with WithEquality should be decoded into a linked list built with the same option.
+
+// MarshalJSON encodes the linked list as a JSON array of its values
+func (l *singlyLinkedList[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(l.ToSlice())
+}
+
+// UnmarshalJSON replaces the values of the linked list with the values of a JSON array
+//
+// the linked list is left unchanged if data is invalid or is the JSON null
+func (l *singlyLinkedList[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	l.replace(values)
+	return nil
+}
+
+// GobEncode encodes the linked list as a gob stream of its values
+func (l *singlyLinkedList[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(l.ToSlice())
+}
+
+// GobDecode replaces the values of the linked list with the values of a gob stream written by GobEncode
+//
+// the linked list is left unchanged if data is invalid
+func (l *singlyLinkedList[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	l.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the linked list in binary form, which is the same as GobEncode
+func (l *singlyLinkedList[T]) MarshalBinary() ([]byte, error) {
+	return l.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (l *singlyLinkedList[T]) UnmarshalBinary(data []byte) error {
+	return l.GobDecode(data)
+}
+
+// replace replaces the values of the linked list with the input values in order
+//
+// O(n + m)
+func (l *singlyLinkedList[T]) replace(values []T) {
+	l.Clear()
+	for _, t := range values {
+		l.AddLast(t)
+	}
+}
+
+// MarshalJSON encodes the linked list as a JSON array of its values
+func (l *doublyLinkedList[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(l.ToSlice())
+}
+
+// UnmarshalJSON replaces the values of the linked list with the values of a JSON array
+//
+// the linked list is left unchanged if data is invalid or is the JSON null
+func (l *doublyLinkedList[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	l.replace(values)
+	return nil
+}
+
+// GobEncode encodes the linked list as a gob stream of its values
+func (l *doublyLinkedList[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(l.ToSlice())
+}
+
+// GobDecode replaces the values of the linked list with the values of a gob stream written by GobEncode
+//
+// the linked list is left unchanged if data is invalid
+func (l *doublyLinkedList[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	l.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the linked list in binary form, which is the same as GobEncode
+func (l *doublyLinkedList[T]) MarshalBinary() ([]byte, error) {
+	return l.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (l *doublyLinkedList[T]) UnmarshalBinary(data []byte) error {
+	return l.GobDecode(data)
+}
+
+// replace replaces the values of the linked list with the input values in order
+//
+// O(n + m)
+func (l *doublyLinkedList[T]) replace(values []T) {
+	l.Clear()
+	for _, t := range values {
+		l.AddLast(t)
+	}
+}
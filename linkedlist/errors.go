@@ -0,0 +1,25 @@
+package linkedlist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConcurrentModification is the error that iterators of a linked list panic with
+// when the linked list is structurally modified during the iteration
+var ErrConcurrentModification = errors.New("linkedlist: concurrent modification")
+
+// ErrNotComparable is the error that the search methods of a linked list panic with
+// when its values are not comparable and no equality was given through WithEquality
+var ErrNotComparable = errors.New("linkedlist: values are not comparable")
+
+// checkModification panics with ErrConcurrentModification if the modification
+// count of a linked list has changed since the start of an iteration
+func checkModification(expected, actual int) {
+	if expected != actual {
+		panic(fmt.Errorf(
+			"%w: linked list was structurally modified during iteration (%d modification(s))",
+			ErrConcurrentModification, actual-expected,
+		))
+	}
+}
@@ -0,0 +1,40 @@
+package linkedlist
+
+import "iter"
+
+// FromSeq returns a new doubly linked list holding the elements of the input sequence in order
+//
+// Example usage:
+// - FromSeq(slices.Values([]int{1, 2, 3}))
+// - FromSeq(maps.Keys(m), WithEquality(strings.EqualFold))
+func FromSeq[T any](seq iter.Seq[T], opts ...Option[T]) DoublyLinkedList[T] {
+	l := NewDoublyLinkedList(opts...)
+	for t := range seq {
+		l.AddLast(t)
+	}
+
+	return l
+}
+
+// From returns a new doubly linked list holding the input values in order
+//
+// Example usage:
+// - From(1, 2, 3)
+func From[T any](values ...T) DoublyLinkedList[T] {
+	return FromSlice(values)
+}
+
+// FromSlice returns a new doubly linked list holding the elements of the input slice in order
+//
+// the slice is not referenced by the linked list and can be reused
+//
+// Example usage:
+// - FromSlice([]string{"a", "b"}, WithEquality(strings.EqualFold))
+func FromSlice[T any](s []T, opts ...Option[T]) DoublyLinkedList[T] {
+	l := NewDoublyLinkedList(opts...)
+	for _, t := range s {
+		l.AddLast(t)
+	}
+
+	return l
+}
@@ -1,6 +1,18 @@
 package linkedlist
 
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+)
+
 // LinkedList represents a linked list
+//
+// the search methods, such as IndexOf, Contains and RemoveAll, compare values with the
+// equality given to the constructor through WithEquality, or with == by default.
+// they panic with an error wrapping ErrNotComparable if T is not comparable and no equality was given.
 type LinkedList[T any] interface {
 	// Add adds input value to the end of the linked list.
 	Add(T)
@@ -51,4 +63,156 @@ type LinkedList[T any] interface {
 	//
 	// ok = false means the index is out of range.
 	DeleteIndex(index int) (ok bool)
+
+	// RemoveIf removes all elements of the linked list that satisfy the input predicate
+	// and returns the number of removed elements.
+	RemoveIf(predicate func(T) bool) (removed int)
+
+	// IndexOf returns the index of the first element equal to the given value.
+	//
+	// ok = false means the value is not in the linked list.
+	IndexOf(t T) (index int, ok bool)
+
+	// LastIndexOf returns the index of the last element equal to the given value.
+	//
+	// ok = false means the value is not in the linked list.
+	LastIndexOf(t T) (index int, ok bool)
+
+	// Contains reports whether the linked list has an element equal to the given value.
+	Contains(t T) bool
+
+	// Find returns the first value of the linked list that satisfies the given predicate.
+	//
+	// ok = false means no value satisfies the predicate.
+	Find(predicate func(T) bool) (t T, ok bool)
+
+	// RemoveFirstMatch removes the first element equal to the given value.
+	//
+	// ok = false means the value is not in the linked list.
+	RemoveFirstMatch(t T) (ok bool)
+
+	// RemoveAll removes all elements equal to the given value and returns the number of removed elements.
+	RemoveAll(t T) (removed int)
+
+	// Sort sorts the linked list in place according to less, using a stable merge sort.
+	Sort(less comparator.Func[T])
+
+	// Reverse reverses the order of the elements of the linked list in place.
+	Reverse()
+
+	// MergeSorted merges the elements of the other linked list into this linked list, both
+	// sorted according to less, leaving the other linked list empty.
+	//
+	// ok = false means other is nil, is this same linked list or is not of the same implementation.
+	MergeSorted(other LinkedList[T], less comparator.Func[T]) (ok bool)
+
+	// Concat moves all elements of the other linked list to the end of this linked list,
+	// leaving the other linked list empty.
+	//
+	// ok = false means other is nil, is this same linked list or is not of the same implementation.
+	Concat(other LinkedList[T]) (ok bool)
+
+	// SpliceAt moves all elements of the other linked list into this linked list, so that
+	// the first of them is at the given index, leaving the other linked list empty.
+	//
+	// ok = false means the index is out of range, which is [0, Size()], or other is nil,
+	// is this same linked list or is not of the same implementation.
+	SpliceAt(index int, other LinkedList[T]) (ok bool)
+
+	// SplitAt moves the elements from the given index to the end of the linked list into
+	// a new linked list of the same implementation and returns it.
+	//
+	// returns nil if the index is out of range, which is [0, Size()].
+	SplitAt(index int) LinkedList[T]
+
+	// MoveRange moves the elements in the index range [from, to) to the end of dst.
+	//
+	// ok = false means the range is invalid, or dst is nil, is this same linked list
+	// or is not of the same implementation.
+	MoveRange(from, to int, dst LinkedList[T]) (ok bool)
+
+	// All returns an iterator over the index-value pairs of the linked list,
+	// from the first element to the last.
+	//
+	// the iterator panics with ErrConcurrentModification if the linked list is
+	// structurally modified during the iteration.
+	All() iter.Seq2[int, T]
+
+	// Values returns an iterator over the values of the linked list,
+	// from the first element to the last.
+	//
+	// the iterator panics with ErrConcurrentModification if the linked list is
+	// structurally modified during the iteration.
+	Values() iter.Seq[T]
+
+	// Backward returns an iterator over the index-value pairs of the linked list,
+	// from the last element to the first.
+	//
+	// the iterator panics with ErrConcurrentModification if the linked list is
+	// structurally modified during the iteration.
+	Backward() iter.Seq2[int, T]
+
+	// ToSlice returns a new slice holding the values of the linked list,
+	// from the first element to the last.
+	ToSlice() []T
+
+	// AppendTo appends the values of the linked list to dst, from the first element
+	// to the last, and returns the extended slice.
+	AppendTo(dst []T) []T
+
+	// The linked list is serialized as the sequence of its values, from the first
+	// element to the last, and decoding replaces its values.
+	json.Marshaler
+	json.Unmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// DoublyLinkedList represents a doubly linked list which, in addition to the
+// LinkedList methods, exposes its elements as handles for O(1) modifications
+type DoublyLinkedList[T any] interface {
+	LinkedList[T]
+
+	// PushFront adds input value to the start of the linked list and returns its element.
+	PushFront(T) *Element[T]
+
+	// PushBack adds input value to the end of the linked list and returns its element.
+	PushBack(T) *Element[T]
+
+	// Front returns the first element of the linked list.
+	//
+	// returns nil if the linked list is empty.
+	Front() *Element[T]
+
+	// Back returns the last element of the linked list.
+	//
+	// returns nil if the linked list is empty.
+	Back() *Element[T]
+
+	// InsertBefore inserts input value right before the mark element and returns the new element.
+	//
+	// returns nil if mark is not an element of the linked list.
+	InsertBefore(t T, mark *Element[T]) *Element[T]
+
+	// InsertAfter inserts input value right after the mark element and returns the new element.
+	//
+	// returns nil if mark is not an element of the linked list.
+	InsertAfter(t T, mark *Element[T]) *Element[T]
+
+	// Remove removes the input element from the linked list.
+	//
+	// ok = false means e is not an element of the linked list.
+	Remove(e *Element[T]) (ok bool)
+
+	// MoveToFront moves the input element to the start of the linked list.
+	//
+	// ok = false means e is not an element of the linked list.
+	MoveToFront(e *Element[T]) (ok bool)
+
+	// MoveToBack moves the input element to the end of the linked list.
+	//
+	// ok = false means e is not an element of the linked list.
+	MoveToBack(e *Element[T]) (ok bool)
 }
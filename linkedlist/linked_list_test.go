@@ -1,7 +1,13 @@
 package linkedlist
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"github.com/stretchr/testify/require"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -25,7 +31,8 @@ func TestNewDoublyLinkedList(t *testing.T) {
 		t.Run(list.name, func(t *testing.T) {
 			list := list.list
 
-			require.Empty(t, list)
+			// the list is not a zero struct, it holds the equality chosen for its type
+			require.Empty(t, list.ToSlice())
 			require.NotNil(t, list)
 
 			require.Equal(t, 0, list.Size())
@@ -468,3 +475,1436 @@ func TestLinkedList_DeleteIndex(t *testing.T) {
 		})
 	}
 }
+
+// tests All method of the linked list
+func TestLinkedList_All(t *testing.T) {
+	lists := []struct {
+		name string
+		list LinkedList[any]
+	}{
+		{
+			name: "singly linked list",
+			list: NewSinglyLinkedList[any](),
+		},
+		{
+			name: "doubly linked list",
+			list: NewDoublyLinkedList[any](),
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			list := list.list
+
+			t.Run("Empty List", func(t *testing.T) {
+				for range list.All() {
+					require.Fail(t, "iterator of an empty list should not yield")
+				}
+			})
+
+			const listSize = 1000
+			for i := 0; i < listSize; i++ {
+				list.Add(i)
+			}
+
+			t.Run("OK", func(t *testing.T) {
+				count := 0
+				for index, value := range list.All() {
+					require.Equal(t, count, index)
+					require.Equal(t, count, value)
+					count++
+				}
+				require.Equal(t, listSize, count)
+			})
+			t.Run("Break", func(t *testing.T) {
+				count := 0
+				for index := range list.All() {
+					if index == listSize/2 {
+						break
+					}
+					count++
+				}
+				require.Equal(t, listSize/2, count)
+			})
+		})
+	}
+}
+
+// tests Values method of the linked list
+func TestLinkedList_Values(t *testing.T) {
+	lists := []struct {
+		name string
+		list LinkedList[any]
+	}{
+		{
+			name: "singly linked list",
+			list: NewSinglyLinkedList[any](),
+		},
+		{
+			name: "doubly linked list",
+			list: NewDoublyLinkedList[any](),
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			list := list.list
+
+			t.Run("Empty List", func(t *testing.T) {
+				for range list.Values() {
+					require.Fail(t, "iterator of an empty list should not yield")
+				}
+			})
+
+			const listSize = 1000
+			for i := 0; i < listSize; i++ {
+				list.Add(i)
+			}
+
+			t.Run("OK", func(t *testing.T) {
+				count := 0
+				for value := range list.Values() {
+					require.Equal(t, count, value)
+					count++
+				}
+				require.Equal(t, listSize, count)
+			})
+			t.Run("Break", func(t *testing.T) {
+				count := 0
+				for value := range list.Values() {
+					if value == listSize/2 {
+						break
+					}
+					count++
+				}
+				require.Equal(t, listSize/2, count)
+			})
+		})
+	}
+}
+
+// tests Backward method of the linked list
+func TestLinkedList_Backward(t *testing.T) {
+	lists := []struct {
+		name string
+		list LinkedList[any]
+	}{
+		{
+			name: "singly linked list",
+			list: NewSinglyLinkedList[any](),
+		},
+		{
+			name: "doubly linked list",
+			list: NewDoublyLinkedList[any](),
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			list := list.list
+
+			t.Run("Empty List", func(t *testing.T) {
+				for range list.Backward() {
+					require.Fail(t, "iterator of an empty list should not yield")
+				}
+			})
+
+			const listSize = 1000
+			for i := 0; i < listSize; i++ {
+				list.Add(i)
+			}
+
+			t.Run("OK", func(t *testing.T) {
+				count := 0
+				for index, value := range list.Backward() {
+					require.Equal(t, listSize-count-1, index)
+					require.Equal(t, listSize-count-1, value)
+					count++
+				}
+				require.Equal(t, listSize, count)
+			})
+			t.Run("Break", func(t *testing.T) {
+				count := 0
+				for index := range list.Backward() {
+					if index == listSize/2 {
+						break
+					}
+					count++
+				}
+				require.Equal(t, listSize-listSize/2-1, count)
+			})
+		})
+	}
+}
+
+// tests element handles of the doubly linked list
+func TestDoublyLinkedList_Element(t *testing.T) {
+	t.Run("PushFront PushBack", func(t *testing.T) {
+		list := NewDoublyLinkedList[int]()
+		require.Nil(t, list.Front())
+		require.Nil(t, list.Back())
+
+		second := list.PushFront(2)
+		first := list.PushFront(1)
+		third := list.PushBack(3)
+
+		require.Equal(t, 3, list.Size())
+		require.Equal(t, []int{1, 2, 3}, slices.Collect(list.Values()))
+
+		require.Equal(t, first, list.Front())
+		require.Equal(t, third, list.Back())
+
+		require.Nil(t, first.Prev())
+		require.Equal(t, second, first.Next())
+		require.Equal(t, first, second.Prev())
+		require.Equal(t, third, second.Next())
+		require.Equal(t, second, third.Prev())
+		require.Nil(t, third.Next())
+
+		second.SetValue(20)
+		require.Equal(t, 20, second.Value())
+		value, ok := list.Get(1)
+		require.True(t, ok)
+		require.Equal(t, 20, value)
+	})
+	t.Run("InsertBefore InsertAfter", func(t *testing.T) {
+		list := NewDoublyLinkedList[int]()
+		mark := list.PushBack(3)
+
+		list.InsertBefore(1, mark)
+		list.InsertBefore(2, mark)
+		list.InsertAfter(5, mark)
+		list.InsertAfter(4, mark)
+
+		require.Equal(t, 5, list.Size())
+		require.Equal(t, []int{1, 2, 3, 4, 5}, slices.Collect(list.Values()))
+
+		first, ok := list.GetFirst()
+		require.True(t, ok)
+		require.Equal(t, 1, first)
+
+		last, ok := list.GetLast()
+		require.True(t, ok)
+		require.Equal(t, 5, last)
+
+		other := NewDoublyLinkedList[int]()
+		otherMark := other.PushBack(0)
+		require.Nil(t, list.InsertBefore(0, otherMark))
+		require.Nil(t, list.InsertAfter(0, otherMark))
+		require.Nil(t, list.InsertAfter(0, nil))
+		require.Equal(t, 5, list.Size())
+	})
+	t.Run("Remove", func(t *testing.T) {
+		list := NewDoublyLinkedList[int]()
+
+		const listSize = 10
+		elements := make([]*Element[int], listSize)
+		for i := 0; i < listSize; i++ {
+			elements[i] = list.PushBack(i)
+		}
+
+		// remove the odd values
+		for i := 1; i < listSize; i += 2 {
+			require.True(t, list.Remove(elements[i]))
+			require.Nil(t, elements[i].Next())
+			require.Nil(t, elements[i].Prev())
+		}
+		require.Equal(t, listSize/2, list.Size())
+		require.Equal(t, []int{0, 2, 4, 6, 8}, slices.Collect(list.Values()))
+
+		// removing an already removed element
+		require.False(t, list.Remove(elements[1]))
+		require.False(t, list.Remove(nil))
+		require.Equal(t, listSize/2, list.Size())
+
+		// remove first and last elements
+		require.True(t, list.Remove(list.Front()))
+		require.True(t, list.Remove(list.Back()))
+		require.Equal(t, []int{2, 4, 6}, slices.Collect(list.Values()))
+		require.Equal(t, []int{6, 4, 2}, backwardValues(list))
+
+		for list.Front() != nil {
+			require.True(t, list.Remove(list.Front()))
+		}
+		require.Equal(t, 0, list.Size())
+		require.Nil(t, list.Back())
+	})
+	t.Run("MoveToFront MoveToBack", func(t *testing.T) {
+		list := NewDoublyLinkedList[int]()
+
+		const listSize = 5
+		elements := make([]*Element[int], listSize)
+		for i := 0; i < listSize; i++ {
+			elements[i] = list.PushBack(i)
+		}
+
+		require.True(t, list.MoveToFront(elements[2]))
+		require.Equal(t, []int{2, 0, 1, 3, 4}, slices.Collect(list.Values()))
+
+		require.True(t, list.MoveToFront(elements[4]))
+		require.Equal(t, []int{4, 2, 0, 1, 3}, slices.Collect(list.Values()))
+
+		require.True(t, list.MoveToFront(elements[4]))
+		require.Equal(t, []int{4, 2, 0, 1, 3}, slices.Collect(list.Values()))
+
+		require.True(t, list.MoveToBack(elements[4]))
+		require.Equal(t, []int{2, 0, 1, 3, 4}, slices.Collect(list.Values()))
+
+		require.True(t, list.MoveToBack(elements[2]))
+		require.Equal(t, []int{0, 1, 3, 4, 2}, slices.Collect(list.Values()))
+		require.Equal(t, []int{2, 4, 3, 1, 0}, backwardValues(list))
+
+		require.Equal(t, listSize, list.Size())
+
+		other := NewDoublyLinkedList[int]()
+		require.False(t, other.MoveToFront(elements[0]))
+		require.False(t, other.MoveToBack(elements[0]))
+	})
+	t.Run("Clear Invalidates Elements", func(t *testing.T) {
+		list := NewDoublyLinkedList[int]()
+		first := list.PushBack(1)
+		list.PushBack(2)
+
+		list.Clear()
+		require.False(t, list.Remove(first))
+		require.Nil(t, first.Next())
+		require.Equal(t, 0, list.Size())
+	})
+}
+
+// backwardValues collects the values of the list from last to first
+func backwardValues[T any](list LinkedList[T]) []T {
+	values := make([]T, 0, list.Size())
+	for _, value := range list.Backward() {
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// tests that iterators of the linked list detect structural modifications
+func TestLinkedList_ConcurrentModification(t *testing.T) {
+	lists := []struct {
+		name    string
+		newList func() LinkedList[any]
+	}{
+		{
+			name: "singly linked list",
+			newList: func() LinkedList[any] {
+				return NewSinglyLinkedList[any]()
+			},
+		},
+		{
+			name: "doubly linked list",
+			newList: func() LinkedList[any] {
+				return NewDoublyLinkedList[any]()
+			},
+		},
+	}
+
+	modifications := []struct {
+		name   string
+		modify func(list LinkedList[any])
+	}{
+		{name: "Add", modify: func(list LinkedList[any]) { list.Add(-1) }},
+		{name: "AddFirst", modify: func(list LinkedList[any]) { list.AddFirst(-1) }},
+		{name: "InsertToIndex", modify: func(list LinkedList[any]) { list.InsertToIndex(-1, 1) }},
+		{name: "DeleteFirst", modify: func(list LinkedList[any]) { list.DeleteFirst() }},
+		{name: "DeleteLast", modify: func(list LinkedList[any]) { list.DeleteLast() }},
+		{name: "DeleteIndex", modify: func(list LinkedList[any]) { list.DeleteIndex(1) }},
+		{name: "Clear", modify: func(list LinkedList[any]) { list.Clear() }},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			for _, modification := range modifications {
+				t.Run(modification.name, func(t *testing.T) {
+					const listSize = 10
+
+					newList := func() LinkedList[any] {
+						l := list.newList()
+						for i := 0; i < listSize; i++ {
+							l.Add(i)
+						}
+						return l
+					}
+
+					l := newList()
+					requireConcurrentModificationPanic(t, func() {
+						for range l.All() {
+							modification.modify(l)
+						}
+					})
+
+					l = newList()
+					requireConcurrentModificationPanic(t, func() {
+						for range l.Values() {
+							modification.modify(l)
+						}
+					})
+
+					l = newList()
+					requireConcurrentModificationPanic(t, func() {
+						for range l.Backward() {
+							modification.modify(l)
+						}
+					})
+				})
+			}
+
+			t.Run("Get Does Not Panic", func(t *testing.T) {
+				l := list.newList()
+				for i := 0; i < 10; i++ {
+					l.Add(i)
+				}
+
+				require.NotPanics(t, func() {
+					for index := range l.All() {
+						l.Get(index)
+					}
+				})
+			})
+		})
+	}
+}
+
+// requireConcurrentModificationPanic asserts that f panics with ErrConcurrentModification
+func requireConcurrentModificationPanic(t *testing.T, f func()) {
+	t.Helper()
+
+	defer func() {
+		r := recover()
+		require.NotNil(t, r)
+
+		err, ok := r.(error)
+		require.True(t, ok)
+		require.ErrorIs(t, err, ErrConcurrentModification)
+	}()
+
+	f()
+}
+
+// tests RemoveIf method of the linked list
+func TestLinkedList_RemoveIf(t *testing.T) {
+	lists := []struct {
+		name    string
+		newList func() LinkedList[int]
+	}{
+		{
+			name: "singly linked list",
+			newList: func() LinkedList[int] {
+				return NewSinglyLinkedList[int]()
+			},
+		},
+		{
+			name: "doubly linked list",
+			newList: func() LinkedList[int] {
+				return NewDoublyLinkedList[int]()
+			},
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			const listSize = 1000
+
+			newList := func() LinkedList[int] {
+				l := list.newList()
+				for i := 0; i < listSize; i++ {
+					l.Add(i)
+				}
+				return l
+			}
+
+			t.Run("Remove None", func(t *testing.T) {
+				l := newList()
+
+				removed := l.RemoveIf(func(value int) bool { return value < 0 })
+				require.Zero(t, removed)
+				require.Equal(t, listSize, l.Size())
+			})
+			t.Run("Remove Odd Values", func(t *testing.T) {
+				l := newList()
+
+				removed := l.RemoveIf(func(value int) bool { return value%2 == 1 })
+				require.Equal(t, listSize/2, removed)
+				require.Equal(t, listSize/2, l.Size())
+
+				index := 0
+				for value := range l.Values() {
+					require.Equal(t, 2*index, value)
+					index++
+				}
+				require.Equal(t, listSize/2, index)
+
+				last, ok := l.GetLast()
+				require.True(t, ok)
+				require.Equal(t, listSize-2, last)
+
+				backward := backwardValues(l)
+				require.Len(t, backward, listSize/2)
+				require.Equal(t, listSize-2, backward[0])
+			})
+			t.Run("Remove First And Last", func(t *testing.T) {
+				l := newList()
+
+				removed := l.RemoveIf(func(value int) bool { return value == 0 || value == listSize-1 })
+				require.Equal(t, 2, removed)
+				require.Equal(t, listSize-2, l.Size())
+
+				first, ok := l.GetFirst()
+				require.True(t, ok)
+				require.Equal(t, 1, first)
+
+				last, ok := l.GetLast()
+				require.True(t, ok)
+				require.Equal(t, listSize-2, last)
+
+				l.Add(listSize)
+				last, ok = l.GetLast()
+				require.True(t, ok)
+				require.Equal(t, listSize, last)
+			})
+			t.Run("Remove All", func(t *testing.T) {
+				l := newList()
+
+				removed := l.RemoveIf(func(int) bool { return true })
+				require.Equal(t, listSize, removed)
+				require.Equal(t, 0, l.Size())
+
+				_, ok := l.GetFirst()
+				require.False(t, ok)
+				_, ok = l.GetLast()
+				require.False(t, ok)
+
+				l.Add(1)
+				require.Equal(t, []int{1}, slices.Collect(l.Values()))
+			})
+		})
+	}
+}
+
+// entry is a value whose key is compared, while its id records its original position
+type entry struct {
+	key int
+	id  int
+}
+
+// newEntryLists returns the linked list implementations for entries, used by the ordering tests
+func newEntryLists() []struct {
+	name    string
+	newList func() LinkedList[entry]
+} {
+	return []struct {
+		name    string
+		newList func() LinkedList[entry]
+	}{
+		{
+			name: "singly linked list",
+			newList: func() LinkedList[entry] {
+				return NewSinglyLinkedList[entry]()
+			},
+		},
+		{
+			name: "doubly linked list",
+			newList: func() LinkedList[entry] {
+				return NewDoublyLinkedList[entry]()
+			},
+		},
+	}
+}
+
+// requireListEqual checks the values of the list in both directions, along with its size and ends
+func requireListEqual[T any](t *testing.T, expected []T, list LinkedList[T]) {
+	require.Equal(t, len(expected), list.Size())
+	if len(expected) == 0 {
+		require.Empty(t, slices.Collect(list.Values()))
+		require.Empty(t, backwardValues(list))
+
+		_, ok := list.GetFirst()
+		require.False(t, ok)
+
+		return
+	}
+
+	require.Equal(t, expected, slices.Collect(list.Values()))
+
+	reversed := slices.Clone(expected)
+	slices.Reverse(reversed)
+	require.Equal(t, reversed, backwardValues(list))
+
+	first, ok := list.GetFirst()
+	require.True(t, ok)
+	require.Equal(t, expected[0], first)
+
+	last, ok := list.GetLast()
+	require.True(t, ok)
+	require.Equal(t, expected[len(expected)-1], last)
+}
+
+func TestLinkedList_Sort(t *testing.T) {
+	byKey := func(t1, t2 entry) bool {
+		return t1.key < t2.key
+	}
+
+	for _, list := range newEntryLists() {
+		t.Run(list.name, func(t *testing.T) {
+			newList := func(entries []entry) LinkedList[entry] {
+				l := list.newList()
+				for _, e := range entries {
+					l.Add(e)
+				}
+				return l
+			}
+
+			t.Run("Empty And Single Element", func(t *testing.T) {
+				l := newList(nil)
+				l.Sort(byKey)
+				requireListEqual(t, nil, l)
+
+				l = newList([]entry{{1, 0}})
+				l.Sort(byKey)
+				requireListEqual(t, []entry{{1, 0}}, l)
+			})
+			t.Run("Randomized Stable", func(t *testing.T) {
+				random := rand.New(rand.NewSource(1))
+
+				for i := 0; i < 50; i++ {
+					entries := make([]entry, random.Intn(300))
+					for j := range entries {
+						entries[j] = entry{key: random.Intn(20), id: j}
+					}
+
+					l := newList(entries)
+					l.Sort(byKey)
+
+					expected := slices.Clone(entries)
+					slices.SortStableFunc(expected, func(e1, e2 entry) int {
+						return e1.key - e2.key
+					})
+					requireListEqual(t, expected, l)
+
+					// the list stays usable at both ends after sorting
+					l.AddLast(entry{key: -1, id: -1})
+					l.AddFirst(entry{key: -2, id: -2})
+					requireListEqual(t, append(append([]entry{{-2, -2}}, expected...), entry{-1, -1}), l)
+				}
+			})
+			t.Run("No Allocations", func(t *testing.T) {
+				random := rand.New(rand.NewSource(1))
+
+				entries := make([]entry, 1000)
+				for j := range entries {
+					entries[j] = entry{key: random.Intn(1000), id: j}
+				}
+
+				l := newList(entries)
+				allocations := testing.AllocsPerRun(10, func() {
+					l.Sort(byKey)
+					l.Reverse()
+				})
+				require.Zero(t, allocations)
+			})
+		})
+	}
+
+	t.Run("Doubly Linked List Elements", func(t *testing.T) {
+		l := NewDoublyLinkedList[int]()
+		elements := []*Element[int]{l.PushBack(3), l.PushBack(1), l.PushBack(2)}
+
+		l.Sort(func(t1, t2 int) bool { return t1 < t2 })
+
+		require.Equal(t, elements[1], l.Front())
+		require.Equal(t, elements[2], l.Front().Next())
+		require.Equal(t, elements[0], l.Back())
+		require.Nil(t, l.Back().Next())
+		require.Nil(t, l.Front().Prev())
+		require.True(t, l.Remove(elements[2]))
+		requireListEqual(t, []int{1, 3}, l)
+	})
+}
+
+func TestLinkedList_Reverse(t *testing.T) {
+	for _, list := range newEntryLists() {
+		t.Run(list.name, func(t *testing.T) {
+			for _, size := range []int{0, 1, 2, 3, 10} {
+				l := list.newList()
+				entries := make([]entry, size)
+				for i := range entries {
+					entries[i] = entry{key: i, id: i}
+					l.Add(entries[i])
+				}
+
+				l.Reverse()
+				slices.Reverse(entries)
+				requireListEqual(t, entries, l)
+
+				l.Reverse()
+				slices.Reverse(entries)
+				requireListEqual(t, entries, l)
+			}
+		})
+	}
+}
+
+func TestLinkedList_MergeSorted(t *testing.T) {
+	byKey := func(t1, t2 entry) bool {
+		return t1.key < t2.key
+	}
+
+	for _, list := range newEntryLists() {
+		t.Run(list.name, func(t *testing.T) {
+			newList := func(entries ...entry) LinkedList[entry] {
+				l := list.newList()
+				for _, e := range entries {
+					l.Add(e)
+				}
+				return l
+			}
+
+			t.Run("Invalid Other", func(t *testing.T) {
+				l := newList(entry{1, 0})
+
+				require.False(t, l.MergeSorted(nil, byKey))
+				require.False(t, l.MergeSorted(l, byKey))
+
+				// lists of different implementations can not be merged
+				for _, other := range newEntryLists() {
+					if other.name != list.name {
+						require.False(t, l.MergeSorted(other.newList(), byKey))
+					}
+				}
+
+				requireListEqual(t, []entry{{1, 0}}, l)
+			})
+			t.Run("Empty Lists", func(t *testing.T) {
+				l := newList()
+				require.True(t, l.MergeSorted(newList(), byKey))
+				requireListEqual(t, nil, l)
+
+				other := newList(entry{1, 0}, entry{2, 0})
+				require.True(t, l.MergeSorted(other, byKey))
+				requireListEqual(t, []entry{{1, 0}, {2, 0}}, l)
+				requireListEqual(t, nil, other)
+
+				require.True(t, l.MergeSorted(newList(), byKey))
+				requireListEqual(t, []entry{{1, 0}, {2, 0}}, l)
+			})
+			t.Run("Stable Merge", func(t *testing.T) {
+				l := newList(entry{1, 0}, entry{2, 0}, entry{2, 1}, entry{5, 0})
+				other := newList(entry{0, 2}, entry{2, 2}, entry{6, 2}, entry{7, 2})
+
+				require.True(t, l.MergeSorted(other, byKey))
+				requireListEqual(t, []entry{
+					{0, 2}, {1, 0}, {2, 0}, {2, 1}, {2, 2}, {5, 0}, {6, 2}, {7, 2},
+				}, l)
+				requireListEqual(t, nil, other)
+
+				// the other list can be reused
+				other.Add(entry{3, 3})
+				requireListEqual(t, []entry{{3, 3}}, other)
+			})
+		})
+	}
+
+	t.Run("Doubly Linked List Elements", func(t *testing.T) {
+		l := NewDoublyLinkedList[int]()
+		l.PushBack(1)
+		l.PushBack(4)
+
+		other := NewDoublyLinkedList[int]()
+		e := other.PushBack(2)
+		other.PushBack(3)
+
+		require.True(t, l.MergeSorted(other, func(t1, t2 int) bool { return t1 < t2 }))
+		requireListEqual(t, []int{1, 2, 3, 4}, l)
+
+		// elements of the other list now belong to this list
+		require.False(t, other.Remove(e))
+		require.True(t, l.Remove(e))
+		requireListEqual(t, []int{1, 3, 4}, l)
+	})
+}
+
+func TestSort_MergeSorted(t *testing.T) {
+	for _, newList := range []func() LinkedList[int]{
+		func() LinkedList[int] { return NewSinglyLinkedList[int]() },
+		func() LinkedList[int] { return NewDoublyLinkedList[int]() },
+	} {
+		l := newList()
+		for _, value := range []int{5, 1, 4, 2} {
+			l.Add(value)
+		}
+		Sort(l)
+		requireListEqual(t, []int{1, 2, 4, 5}, l)
+
+		other := newList()
+		other.Add(0)
+		other.Add(3)
+		other.Add(6)
+		require.True(t, MergeSorted(l, other))
+		requireListEqual(t, []int{0, 1, 2, 3, 4, 5, 6}, l)
+		requireListEqual(t, nil, other)
+
+		require.False(t, MergeSorted(l, l))
+	}
+}
+
+func TestLinkedList_Splice(t *testing.T) {
+	lists := []struct {
+		name    string
+		newList func() LinkedList[int]
+	}{
+		{
+			name: "singly linked list",
+			newList: func() LinkedList[int] {
+				return NewSinglyLinkedList[int]()
+			},
+		},
+		{
+			name: "doubly linked list",
+			newList: func() LinkedList[int] {
+				return NewDoublyLinkedList[int]()
+			},
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			newList := func(values ...int) LinkedList[int] {
+				l := list.newList()
+				for _, value := range values {
+					l.Add(value)
+				}
+				return l
+			}
+
+			t.Run("Invalid Arguments", func(t *testing.T) {
+				l := newList(1, 2, 3)
+				other := newList(4)
+
+				require.False(t, l.Concat(nil))
+				require.False(t, l.Concat(l))
+				require.False(t, l.SpliceAt(-1, other))
+				require.False(t, l.SpliceAt(4, other))
+				require.False(t, l.SpliceAt(0, l))
+				require.Nil(t, l.SplitAt(-1))
+				require.Nil(t, l.SplitAt(4))
+				require.False(t, l.MoveRange(-1, 2, other))
+				require.False(t, l.MoveRange(0, 4, other))
+				require.False(t, l.MoveRange(2, 1, other))
+				require.False(t, l.MoveRange(0, 1, l))
+				require.False(t, l.MoveRange(0, 1, nil))
+
+				// lists of different implementations can not be spliced
+				for _, different := range lists {
+					if different.name != list.name {
+						require.False(t, l.Concat(different.newList()))
+						require.False(t, l.MoveRange(0, 1, different.newList()))
+					}
+				}
+
+				requireListEqual(t, []int{1, 2, 3}, l)
+				requireListEqual(t, []int{4}, other)
+			})
+			t.Run("Concat", func(t *testing.T) {
+				l := newList()
+				other := newList(1, 2)
+
+				require.True(t, l.Concat(other))
+				requireListEqual(t, []int{1, 2}, l)
+				requireListEqual(t, nil, other)
+
+				require.True(t, l.Concat(other))
+				requireListEqual(t, []int{1, 2}, l)
+
+				other.Add(3)
+				require.True(t, l.Concat(other))
+				requireListEqual(t, []int{1, 2, 3}, l)
+				requireListEqual(t, nil, other)
+
+				// both lists stay usable
+				l.Add(4)
+				other.Add(5)
+				requireListEqual(t, []int{1, 2, 3, 4}, l)
+				requireListEqual(t, []int{5}, other)
+			})
+			t.Run("SpliceAt", func(t *testing.T) {
+				testCases := []struct {
+					name     string
+					index    int
+					expected []int
+				}{
+					{name: "Start", index: 0, expected: []int{7, 8, 1, 2, 3}},
+					{name: "Middle", index: 1, expected: []int{1, 7, 8, 2, 3}},
+					{name: "Before Last", index: 2, expected: []int{1, 2, 7, 8, 3}},
+					{name: "End", index: 3, expected: []int{1, 2, 3, 7, 8}},
+				}
+
+				for _, tc := range testCases {
+					t.Run(tc.name, func(t *testing.T) {
+						l := newList(1, 2, 3)
+						other := newList(7, 8)
+
+						require.True(t, l.SpliceAt(tc.index, other))
+						requireListEqual(t, tc.expected, l)
+						requireListEqual(t, nil, other)
+					})
+				}
+			})
+			t.Run("SplitAt", func(t *testing.T) {
+				for index := 0; index <= 5; index++ {
+					values := []int{1, 2, 3, 4, 5}
+					l := newList(values...)
+
+					split := l.SplitAt(index)
+					require.NotNil(t, split)
+					requireListEqual(t, values[:index], l)
+					requireListEqual(t, values[index:], split)
+
+					// both lists stay usable
+					l.Add(6)
+					split.AddFirst(0)
+					requireListEqual(t, append(slices.Clone(values[:index]), 6), l)
+					requireListEqual(t, append([]int{0}, values[index:]...), split)
+				}
+			})
+			t.Run("MoveRange", func(t *testing.T) {
+				for from := 0; from <= 4; from++ {
+					for to := from; to <= 4; to++ {
+						values := []int{1, 2, 3, 4}
+						l := newList(values...)
+						dst := newList(10)
+
+						require.True(t, l.MoveRange(from, to, dst))
+						requireListEqual(t, append(slices.Clone(values[:from]), values[to:]...), l)
+						requireListEqual(t, append([]int{10}, values[from:to]...), dst)
+					}
+				}
+			})
+			t.Run("Randomized", func(t *testing.T) {
+				random := rand.New(rand.NewSource(1))
+
+				// the lists are checked against slices holding their expected values
+				const listCount = 4
+				lists := make([]LinkedList[int], listCount)
+				expected := make([][]int, listCount)
+				for i := range lists {
+					lists[i] = newList()
+				}
+
+				next := 0
+				for i := 0; i < 2000; i++ {
+					a, b := random.Intn(listCount), random.Intn(listCount)
+					if a == b {
+						continue
+					}
+
+					switch random.Intn(5) {
+					case 0:
+						for j := random.Intn(5); j > 0; j-- {
+							lists[a].Add(next)
+							expected[a] = append(expected[a], next)
+							next++
+						}
+					case 1:
+						require.True(t, lists[a].Concat(lists[b]))
+						expected[a] = append(expected[a], expected[b]...)
+						expected[b] = nil
+					case 2:
+						index := random.Intn(len(expected[a]) + 1)
+						require.True(t, lists[a].SpliceAt(index, lists[b]))
+						expected[a] = slices.Insert(expected[a], index, expected[b]...)
+						expected[b] = nil
+					case 3:
+						index := random.Intn(len(expected[a]) + 1)
+						lists[b] = lists[a].SplitAt(index)
+						expected[b] = slices.Clone(expected[a][index:])
+						expected[a] = expected[a][:index]
+					case 4:
+						from := random.Intn(len(expected[a]) + 1)
+						to := from + random.Intn(len(expected[a])-from+1)
+						require.True(t, lists[a].MoveRange(from, to, lists[b]))
+						expected[b] = append(expected[b], expected[a][from:to]...)
+						expected[a] = slices.Delete(expected[a], from, to)
+					}
+
+					requireListEqual(t, expected[a], lists[a])
+					requireListEqual(t, expected[b], lists[b])
+				}
+			})
+		})
+	}
+
+	t.Run("Doubly Linked List Elements", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		const listCount = 4
+		lists := make([]DoublyLinkedList[int], listCount)
+		for i := range lists {
+			lists[i] = NewDoublyLinkedList[int]()
+		}
+
+		// the element of each value, and the index of the list that holds it, -1 for none
+		var elements []*Element[int]
+		var owners []int
+
+		for i := 0; i < 2000; i++ {
+			a, b := random.Intn(listCount), random.Intn(listCount)
+			if a == b {
+				continue
+			}
+
+			switch random.Intn(5) {
+			case 0:
+				elements = append(elements, lists[a].PushBack(len(elements)))
+				owners = append(owners, a)
+			case 1:
+				require.True(t, lists[a].Concat(lists[b]))
+			case 2:
+				require.True(t, lists[a].SpliceAt(random.Intn(lists[a].Size()+1), lists[b]))
+			case 3:
+				index := random.Intn(lists[a].Size() + 1)
+				split := lists[a].SplitAt(index)
+				require.NotNil(t, split)
+
+				lists[b] = split.(DoublyLinkedList[int])
+			case 4:
+				from := random.Intn(lists[a].Size() + 1)
+				to := from + random.Intn(lists[a].Size()-from+1)
+				require.True(t, lists[a].MoveRange(from, to, lists[b]))
+			}
+
+			// recompute the owner of each element from the contents of the lists
+			for j := range owners {
+				owners[j] = -1
+			}
+			for j, l := range lists {
+				for value := range l.Values() {
+					owners[value] = j
+				}
+			}
+
+			// an element can be moved only by the list that holds it, moving it to its own back
+			if len(elements) > 0 {
+				value := random.Intn(len(elements))
+				for j, l := range lists {
+					require.Equal(t, owners[value] == j, l.MoveToBack(elements[value]))
+				}
+			}
+		}
+
+		// every element can still be removed through the list that holds it
+		for value, e := range elements {
+			if owners[value] >= 0 {
+				require.True(t, lists[owners[value]].Remove(e))
+			}
+		}
+		for _, l := range lists {
+			require.Zero(t, l.Size())
+		}
+	})
+}
+
+func TestLinkedList_Search(t *testing.T) {
+	lists := []struct {
+		name    string
+		newList func(opts ...Option[string]) LinkedList[string]
+	}{
+		{
+			name: "singly linked list",
+			newList: func(opts ...Option[string]) LinkedList[string] {
+				return NewSinglyLinkedList(opts...)
+			},
+		},
+		{
+			name: "doubly linked list",
+			newList: func(opts ...Option[string]) LinkedList[string] {
+				return NewDoublyLinkedList(opts...)
+			},
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			newList := func(opts ...Option[string]) LinkedList[string] {
+				l := list.newList(opts...)
+				for _, value := range []string{"a", "B", "c", "b", "a"} {
+					l.Add(value)
+				}
+				return l
+			}
+
+			t.Run("IndexOf", func(t *testing.T) {
+				l := newList()
+
+				testCases := []struct {
+					value string
+					first int
+					last  int
+					ok    bool
+				}{
+					{value: "a", first: 0, last: 4, ok: true},
+					{value: "b", first: 3, last: 3, ok: true},
+					{value: "B", first: 1, last: 1, ok: true},
+					{value: "d", ok: false},
+				}
+
+				for _, tc := range testCases {
+					index, ok := l.IndexOf(tc.value)
+					require.Equal(t, tc.ok, ok)
+					require.Equal(t, tc.first, index)
+
+					index, ok = l.LastIndexOf(tc.value)
+					require.Equal(t, tc.ok, ok)
+					require.Equal(t, tc.last, index)
+
+					require.Equal(t, tc.ok, l.Contains(tc.value))
+
+					// the package level functions give the same results for comparable values
+					index, ok = IndexOf(l, tc.value)
+					require.Equal(t, tc.ok, ok)
+					require.Equal(t, tc.first, index)
+
+					index, ok = LastIndexOf(l, tc.value)
+					require.Equal(t, tc.ok, ok)
+					require.Equal(t, tc.last, index)
+
+					require.Equal(t, tc.ok, Contains(l, tc.value))
+				}
+			})
+			t.Run("Find", func(t *testing.T) {
+				l := newList()
+
+				value, ok := l.Find(func(value string) bool { return strings.ToUpper(value) == value })
+				require.True(t, ok)
+				require.Equal(t, "B", value)
+
+				_, ok = l.Find(func(value string) bool { return value == "" })
+				require.False(t, ok)
+			})
+			t.Run("RemoveFirstMatch", func(t *testing.T) {
+				l := newList()
+
+				require.True(t, l.RemoveFirstMatch("a"))
+				requireListEqual(t, []string{"B", "c", "b", "a"}, l)
+
+				require.True(t, l.RemoveFirstMatch("a"))
+				requireListEqual(t, []string{"B", "c", "b"}, l)
+
+				require.False(t, l.RemoveFirstMatch("a"))
+				requireListEqual(t, []string{"B", "c", "b"}, l)
+
+				require.True(t, l.RemoveFirstMatch("b"))
+				requireListEqual(t, []string{"B", "c"}, l)
+			})
+			t.Run("RemoveAll", func(t *testing.T) {
+				l := newList()
+
+				require.Equal(t, 2, l.RemoveAll("a"))
+				requireListEqual(t, []string{"B", "c", "b"}, l)
+
+				require.Zero(t, l.RemoveAll("a"))
+				requireListEqual(t, []string{"B", "c", "b"}, l)
+			})
+			t.Run("WithEquality", func(t *testing.T) {
+				l := newList(WithEquality(strings.EqualFold))
+
+				index, ok := l.IndexOf("b")
+				require.True(t, ok)
+				require.Equal(t, 1, index)
+
+				index, ok = l.LastIndexOf("A")
+				require.True(t, ok)
+				require.Equal(t, 4, index)
+
+				require.True(t, l.Contains("C"))
+
+				// the package level functions always compare with ==
+				require.False(t, Contains(l, "C"))
+
+				require.True(t, l.RemoveFirstMatch("b"))
+				requireListEqual(t, []string{"a", "c", "b", "a"}, l)
+
+				require.Equal(t, 2, l.RemoveAll("A"))
+				requireListEqual(t, []string{"c", "b"}, l)
+
+				// the equality is kept by split lists
+				l.Add("D")
+				split := l.SplitAt(1)
+				require.True(t, split.Contains("d"))
+			})
+		})
+	}
+
+	t.Run("Default Equality", func(t *testing.T) {
+		type point struct{ x, y int }
+
+		ints := NewSinglyLinkedList[int]()
+		points := NewDoublyLinkedList[point]()
+		values := NewSinglyLinkedList[any]()
+		for i := 0; i < 10; i++ {
+			ints.Add(i)
+			points.Add(point{i, i})
+			values.Add(i)
+		}
+
+		require.True(t, ints.Contains(9))
+		require.False(t, ints.Contains(10))
+		require.True(t, points.Contains(point{9, 9}))
+		require.False(t, points.Contains(point{9, 0}))
+		require.True(t, values.Contains(9))
+		require.False(t, values.Contains("9"))
+
+		// the search does not allocate
+		require.Zero(t, testing.AllocsPerRun(100, func() {
+			ints.Contains(10)
+		}))
+
+		// an interface type passes the check in the constructor, but its dynamic values may not be comparable
+		require.Panics(t, func() {
+			values.Add([]int{1})
+			values.Contains([]int{1})
+		})
+	})
+	t.Run("Incomparable Values", func(t *testing.T) {
+		l := NewSinglyLinkedList[[]int]()
+		l.Add([]int{1})
+
+		for name, search := range map[string]func(){
+			"Contains":         func() { l.Contains([]int{1}) },
+			"IndexOf":          func() { l.IndexOf([]int{1}) },
+			"LastIndexOf":      func() { l.LastIndexOf([]int{1}) },
+			"RemoveFirstMatch": func() { l.RemoveFirstMatch([]int{1}) },
+			"RemoveAll":        func() { l.RemoveAll([]int{1}) },
+		} {
+			t.Run(name, func(t *testing.T) {
+				defer func() {
+					err, ok := recover().(error)
+					require.True(t, ok)
+					require.ErrorIs(t, err, ErrNotComparable)
+				}()
+
+				search()
+			})
+		}
+
+		// the other methods work without an equality
+		doubly := NewDoublyLinkedList[[]int]()
+		doubly.Add([]int{1})
+		require.Equal(t, [][]int{{1}}, doubly.ToSlice())
+		require.Panics(t, func() {
+			doubly.Contains([]int{1})
+		})
+
+		// the split list keeps failing clearly
+		doubly.Add([]int{2})
+		require.PanicsWithError(t, "linkedlist: values are not comparable: []int, use WithEquality to search a linked list of this type", func() {
+			doubly.SplitAt(1).Contains([]int{2})
+		})
+
+		l = NewSinglyLinkedList(WithEquality(slices.Equal[[]int]))
+		l.Add([]int{1})
+		require.True(t, l.Contains([]int{1}))
+	})
+}
+
+func TestEqual(t *testing.T) {
+	newLists := func(values ...int) (LinkedList[int], LinkedList[int]) {
+		singly, doubly := NewSinglyLinkedList[int](), NewDoublyLinkedList[int]()
+		for _, value := range values {
+			singly.Add(value)
+			doubly.Add(value)
+		}
+		return singly, doubly
+	}
+
+	singly, doubly := newLists(1, 2, 3)
+	require.True(t, Equal(singly, doubly))
+	require.True(t, Equal(doubly, singly))
+	require.True(t, Equal(singly, singly))
+
+	doubly.AddLast(4)
+	require.False(t, Equal(singly, doubly))
+
+	doubly.DeleteLast()
+	doubly.DeleteFirst()
+	doubly.AddFirst(0)
+	require.False(t, Equal(singly, doubly))
+
+	empty, _ := newLists()
+	require.True(t, Equal(empty, nil))
+	require.True(t, Equal[int](nil, nil))
+	require.False(t, Equal(singly, nil))
+
+	modulo, _ := newLists(4, 5, 6)
+	require.True(t, EqualFunc(singly, modulo, func(t1, t2 int) bool {
+		return t1%3 == t2%3
+	}))
+	require.False(t, EqualFunc(singly, modulo, func(t1, t2 int) bool {
+		return t1 == t2
+	}))
+
+	// the nodes are walked directly, without an iterator
+	equalInts := func(t1, t2 int) bool {
+		return t1 == t2
+	}
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		EqualFunc(singly, modulo, equalInts)
+	}))
+
+	// linked lists implemented outside the package are walked through their iterators
+	type wrapper struct {
+		LinkedList[int]
+	}
+	singly, doubly = newLists(1, 2, 3)
+	different, _ := newLists(1, 2, 4)
+	require.True(t, Equal[int](wrapper{singly}, doubly))
+	require.True(t, Equal[int](singly, wrapper{doubly}))
+	require.True(t, Equal[int](wrapper{singly}, wrapper{doubly}))
+	require.False(t, Equal[int](wrapper{singly}, different))
+	require.False(t, Equal[int](different, wrapper{doubly}))
+	require.False(t, Equal[int](wrapper{different}, wrapper{doubly}))
+}
+
+func TestFromSeq(t *testing.T) {
+	l := FromSeq(slices.Values([]int{1, 2, 3}))
+	requireListEqual(t, []int{1, 2, 3}, l)
+	require.NotNil(t, l.Front())
+
+	requireListEqual(t, nil, FromSeq(slices.Values([]int{})))
+
+	// options are passed to the linked list
+	folded := FromSeq(slices.Values([]string{"A", "b"}), WithEquality(strings.EqualFold))
+	require.True(t, folded.Contains("a"))
+}
+
+func TestLinkedList_ToSlice(t *testing.T) {
+	lists := []struct {
+		name    string
+		newList func() LinkedList[int]
+	}{
+		{
+			name:    "singly linked list",
+			newList: func() LinkedList[int] { return NewSinglyLinkedList[int]() },
+		},
+		{
+			name:    "doubly linked list",
+			newList: func() LinkedList[int] { return NewDoublyLinkedList[int]() },
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			l := list.newList()
+			require.Equal(t, []int{}, l.ToSlice())
+			require.Nil(t, l.AppendTo(nil))
+
+			for i := 0; i < 5; i++ {
+				l.Add(i)
+			}
+
+			require.Equal(t, []int{0, 1, 2, 3, 4}, l.ToSlice())
+			require.Equal(t, []int{-1, 0, 1, 2, 3, 4}, l.AppendTo([]int{-1}))
+
+			// dst with enough capacity is extended in place
+			dst := make([]int, 1, 10)
+			extended := l.AppendTo(dst)
+			require.Equal(t, &dst[:2][1], &extended[1])
+		})
+	}
+}
+
+func TestFrom_FromSlice(t *testing.T) {
+	requireListEqual(t, []int{1, 2, 3}, From(1, 2, 3))
+	requireListEqual(t, nil, From[int]())
+	requireListEqual(t, nil, FromSlice[int](nil))
+
+	// the input slice is not referenced by the linked list
+	data := []int{1, 2, 3}
+	l := FromSlice(data)
+	data[0] = 10
+	requireListEqual(t, []int{1, 2, 3}, l)
+
+	// options are passed to the linked list
+	folded := FromSlice([]string{"A", "b"}, WithEquality(strings.EqualFold))
+	require.True(t, folded.Contains("a"))
+
+	// data moves between lists without loops
+	requireListEqual(t, []int{1, 2, 3}, FromSlice(l.ToSlice()))
+}
+
+func TestLinkedList_Encoding(t *testing.T) {
+	lists := []struct {
+		name    string
+		newList func() LinkedList[int]
+	}{
+		{
+			name:    "singly linked list",
+			newList: func() LinkedList[int] { return NewSinglyLinkedList[int]() },
+		},
+		{
+			name:    "doubly linked list",
+			newList: func() LinkedList[int] { return NewDoublyLinkedList[int]() },
+		},
+	}
+
+	for _, list := range lists {
+		t.Run(list.name, func(t *testing.T) {
+			l := list.newList()
+			for i := 0; i < 5; i++ {
+				l.Add(i)
+			}
+
+			data, err := json.Marshal(l)
+			require.NoError(t, err)
+			require.JSONEq(t, `[0, 1, 2, 3, 4]`, string(data))
+
+			decoded := list.newList()
+			decoded.Add(100)
+			require.NoError(t, json.Unmarshal(data, decoded))
+			requireListEqual(t, []int{0, 1, 2, 3, 4}, decoded)
+
+			// null and invalid data leave the linked list unchanged
+			require.NoError(t, json.Unmarshal([]byte(`null`), decoded))
+			require.Error(t, json.Unmarshal([]byte(`["a"]`), decoded))
+			requireListEqual(t, []int{0, 1, 2, 3, 4}, decoded)
+
+			// an empty array clears the linked list
+			require.NoError(t, json.Unmarshal([]byte(`[]`), decoded))
+			requireListEqual(t, nil, decoded)
+
+			var buffer bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buffer).Encode(l))
+			decoded = list.newList()
+			require.NoError(t, gob.NewDecoder(&buffer).Decode(decoded))
+			requireListEqual(t, []int{0, 1, 2, 3, 4}, decoded)
+
+			binary, err := l.MarshalBinary()
+			require.NoError(t, err)
+			decoded = list.newList()
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			requireListEqual(t, []int{0, 1, 2, 3, 4}, decoded)
+			require.Error(t, decoded.UnmarshalBinary([]byte("invalid")))
+			requireListEqual(t, []int{0, 1, 2, 3, 4}, decoded)
+
+			// an empty linked list encodes to an empty one
+			binary, err = list.newList().MarshalBinary()
+			require.NoError(t, err)
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			requireListEqual(t, nil, decoded)
+		})
+	}
+
+	// decoding keeps the equality of the linked list
+	folded := NewDoublyLinkedList(WithEquality(strings.EqualFold))
+	require.NoError(t, json.Unmarshal([]byte(`["A", "b"]`), folded))
+	require.True(t, folded.Contains("a"))
+
+	// elements of the replaced values do not belong to the linked list anymore
+	l := From(1, 2)
+	front := l.Front()
+	require.NoError(t, json.Unmarshal([]byte(`[3]`), l))
+	require.False(t, l.Remove(front))
+	requireListEqual(t, []int{3}, l)
+}
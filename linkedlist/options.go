@@ -0,0 +1,108 @@
+package linkedlist
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Option configures a linked list created by NewSinglyLinkedList or NewDoublyLinkedList.
+type Option[T any] func(*options[T])
+
+// options holds the configuration of a linked list.
+type options[T any] struct {
+	equal func(t1, t2 T) bool
+}
+
+// WithEquality sets the function used by the search methods of the linked list,
+// such as IndexOf, Contains and RemoveAll, to compare values.
+//
+// by default values are compared with ==. if T is not comparable, such as a slice or a map,
+// the search methods panic with an error wrapping ErrNotComparable unless an equality is given.
+func WithEquality[T any](equal func(t1, t2 T) bool) Option[T] {
+	return func(o *options[T]) {
+		o.equal = equal
+	}
+}
+
+// newOptions applies the input options to a zero configuration
+//
+// if no equality is given, the default one is chosen once here for T: == if T is comparable,
+// otherwise one that fails with ErrNotComparable instead of a runtime panic on ==
+func newOptions[T any](opts []Option[T]) options[T] {
+	var o options[T]
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.equal == nil {
+		if reflect.TypeFor[T]().Comparable() {
+			o.equal = comparableEqual[T]()
+		} else {
+			o.equal = notComparable[T]
+		}
+	}
+
+	return o
+}
+
+// comparableEqual returns the equality of a linked list of a comparable type T, which compares with ==
+//
+// the predeclared comparable types are compared directly, other types are compared as interface values,
+// which can still panic for an interface type holding values that are not comparable
+func comparableEqual[T any]() func(t1, t2 T) bool {
+	var equal any
+	switch any(*new(T)).(type) {
+	case bool:
+		equal = equalDirect[bool]
+	case string:
+		equal = equalDirect[string]
+	case int:
+		equal = equalDirect[int]
+	case int8:
+		equal = equalDirect[int8]
+	case int16:
+		equal = equalDirect[int16]
+	case int32:
+		equal = equalDirect[int32]
+	case int64:
+		equal = equalDirect[int64]
+	case uint:
+		equal = equalDirect[uint]
+	case uint8:
+		equal = equalDirect[uint8]
+	case uint16:
+		equal = equalDirect[uint16]
+	case uint32:
+		equal = equalDirect[uint32]
+	case uint64:
+		equal = equalDirect[uint64]
+	case uintptr:
+		equal = equalDirect[uintptr]
+	case float32:
+		equal = equalDirect[float32]
+	case float64:
+		equal = equalDirect[float64]
+	}
+
+	if equal, ok := equal.(func(t1, t2 T) bool); ok {
+		return equal
+	}
+
+	return func(t1, t2 T) bool {
+		return any(t1) == any(t2)
+	}
+}
+
+// equalDirect compares two values of a comparable type with ==
+func equalDirect[C comparable](t1, t2 C) bool {
+	return t1 == t2
+}
+
+// notComparable is the equality of a linked list of a type that is not comparable,
+// it panics with ErrNotComparable
+func notComparable[T any](_, _ T) bool {
+	panic(fmt.Errorf(
+		"%w: %v, use WithEquality to search a linked list of this type",
+		ErrNotComparable, reflect.TypeFor[T](),
+	))
+}
@@ -0,0 +1,140 @@
+package linkedlist
+
+import "iter"
+
+// IndexOf returns the index of the first element of the linked list equal to the input value,
+// compared with ==, regardless of the equality the linked list was created with
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func IndexOf[T comparable](l LinkedList[T], t T) (index int, ok bool) {
+	for i, value := range l.All() {
+		if value == t {
+			return i, true
+		}
+	}
+
+	return
+}
+
+// LastIndexOf returns the index of the last element of the linked list equal to the input value,
+// compared with ==, regardless of the equality the linked list was created with
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func LastIndexOf[T comparable](l LinkedList[T], t T) (index int, ok bool) {
+	for i, value := range l.All() {
+		if value == t {
+			index, ok = i, true
+		}
+	}
+
+	return
+}
+
+// Contains reports whether the linked list has an element equal to the input value,
+// compared with ==, regardless of the equality the linked list was created with
+//
+// O(n)
+func Contains[T comparable](l LinkedList[T], t T) bool {
+	_, ok := IndexOf(l, t)
+	return ok
+}
+
+// Equal reports whether two linked lists have the same size and equal elements in the same order,
+// compared with ==, the linked lists can be of different implementations
+//
+// a nil linked list is considered empty
+// O(n)
+func Equal[T comparable](a, b LinkedList[T]) bool {
+	return EqualFunc(a, b, func(t1, t2 T) bool {
+		return t1 == t2
+	})
+}
+
+// EqualFunc reports whether two linked lists have the same size and equal elements in the same order,
+// compared with the input equality function, the linked lists can be of different implementations
+//
+// a nil linked list is considered empty
+// O(n)
+func EqualFunc[T any](a, b LinkedList[T], equal func(t1, t2 T) bool) bool {
+	if size(a) != size(b) {
+		return false
+	}
+	if size(a) == 0 {
+		return true
+	}
+
+	cursorA, okA := newCursor(a)
+	cursorB, okB := newCursor(b)
+	if !okA || !okB {
+		return equalValues(a, b, equal)
+	}
+
+	for range a.Size() {
+		if !equal(cursorA.next(), cursorB.next()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equalValues reports whether two non-empty linked lists of the same size have equal values in the same order,
+// walking them in step through their iterators, for linked lists that are not implemented by this package
+func equalValues[T any](a, b LinkedList[T], equal func(t1, t2 T) bool) bool {
+	next, stop := iter.Pull(b.Values())
+	defer stop()
+
+	for value := range a.Values() {
+		other, _ := next()
+		if !equal(value, other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cursor walks the values of a linked list implemented by this package node by node,
+// only the node of the implementation of the linked list is set
+type cursor[T any] struct {
+	singly *singlyNode[T]
+	doubly *Element[T]
+}
+
+// newCursor returns a cursor at the first node of the input linked list
+//
+// ok = false means the linked list is not implemented by this package
+func newCursor[T any](l LinkedList[T]) (c cursor[T], ok bool) {
+	switch l := l.(type) {
+	case *singlyLinkedList[T]:
+		return cursor[T]{singly: l.first}, true
+	case *doublyLinkedList[T]:
+		return cursor[T]{doubly: l.first}, true
+	}
+
+	return c, false
+}
+
+// next returns the value of the node at the cursor and moves the cursor to the next node
+//
+// should not be called once the cursor is past the last node
+func (c *cursor[T]) next() (t T) {
+	if c.singly != nil {
+		t, c.singly = c.singly.value, c.singly.next
+		return
+	}
+
+	t, c.doubly = c.doubly.value, c.doubly.next
+	return
+}
+
+// size returns the size of the input linked list, 0 for nil
+func size[T any](l LinkedList[T]) int {
+	if l == nil {
+		return 0
+	}
+
+	return l.Size()
+}
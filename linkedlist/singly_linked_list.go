@@ -1,5 +1,11 @@
 package linkedlist
 
+import (
+	"github.com/TheFeij/go-collections/comparator"
+	"iter"
+	"slices"
+)
+
 // singlyNode represents a node in a singly linked list
 type singlyNode[T any] struct {
 	value T
@@ -11,6 +17,13 @@ type singlyLinkedList[T any] struct {
 	first *singlyNode[T]
 	last  *singlyNode[T]
 	size  int
+
+	// modCount counts the structural modifications of the linked list,
+	// used by iterators to detect modifications during iteration
+	modCount int
+
+	// equal compares values for the search methods, set by the constructor
+	equal func(t1, t2 T) bool
 }
 
 // InsertToIndex inserts input value to the given index
@@ -36,6 +49,7 @@ func (l *singlyLinkedList[T]) InsertToIndex(t T, index int) (ok bool) {
 
 		// update the size
 		l.size += 1
+		l.modCount += 1
 	}
 
 	return true
@@ -73,6 +87,7 @@ func (l *singlyLinkedList[T]) DeleteIndex(index int) (ok bool) {
 
 	// update the size
 	l.size -= 1
+	l.modCount += 1
 
 	return true
 }
@@ -127,6 +142,7 @@ func (l *singlyLinkedList[T]) Clear() {
 	l.first = nil
 	l.last = nil
 	l.size = 0
+	l.modCount += 1
 }
 
 // GetFirst returns the first element of the linked list
@@ -169,6 +185,7 @@ func (l *singlyLinkedList[T]) AddFirst(t T) {
 
 	l.first = newNode
 	l.size += 1
+	l.modCount += 1
 }
 
 // AddLast adds input value to the end of the linked list
@@ -186,6 +203,7 @@ func (l *singlyLinkedList[T]) AddLast(t T) {
 
 	l.last = newNode
 	l.size += 1
+	l.modCount += 1
 }
 
 // DeleteFirst deletes first element of the linked list
@@ -210,6 +228,7 @@ func (l *singlyLinkedList[T]) deleteFirst() {
 		l.first = nil
 		l.last = nil
 		l.size = 0
+		l.modCount += 1
 		return
 	}
 
@@ -225,6 +244,7 @@ func (l *singlyLinkedList[T]) deleteFirst() {
 	first = nil
 
 	l.size -= 1
+	l.modCount += 1
 }
 
 // DeleteLast deletes last element of the linked list
@@ -249,6 +269,7 @@ func (l *singlyLinkedList[T]) deleteLast() {
 		l.first = nil
 		l.last = nil
 		l.size = 0
+		l.modCount += 1
 		return
 	}
 
@@ -262,13 +283,513 @@ func (l *singlyLinkedList[T]) deleteLast() {
 	l.last = secondLast
 
 	l.size -= 1
+	l.modCount += 1
+}
+
+// RemoveIf removes all elements of the linked list that satisfy the input predicate
+// and returns the number of removed elements
+//
+// O(n)
+func (l *singlyLinkedList[T]) RemoveIf(predicate func(T) bool) (removed int) {
+	var previous *singlyNode[T]
+
+	currNode := l.first
+	for currNode != nil {
+		next := currNode.next
+
+		if predicate(currNode.value) {
+			if previous == nil {
+				l.first = next
+			} else {
+				previous.next = next
+			}
+
+			// clear references to help garbage collection
+			currNode.next = nil
+
+			removed++
+		} else {
+			previous = currNode
+		}
+
+		currNode = next
+	}
+
+	if removed == 0 {
+		return
+	}
+
+	l.last = previous
+	l.size -= removed
+	l.modCount += 1
+
+	return
+}
+
+// IndexOf returns the index of the first element equal to the input value
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func (l *singlyLinkedList[T]) IndexOf(t T) (index int, ok bool) {
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if l.equal(currNode.value, t) {
+			return index, true
+		}
+
+		index++
+	}
+
+	return 0, false
+}
+
+// LastIndexOf returns the index of the last element equal to the input value
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func (l *singlyLinkedList[T]) LastIndexOf(t T) (index int, ok bool) {
+	currIndex := 0
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if l.equal(currNode.value, t) {
+			index, ok = currIndex, true
+		}
+
+		currIndex++
+	}
+
+	return
+}
+
+// Contains reports whether the linked list has an element equal to the input value
+//
+// O(n)
+func (l *singlyLinkedList[T]) Contains(t T) bool {
+	_, ok := l.IndexOf(t)
+	return ok
+}
+
+// Find returns the first value of the linked list that satisfies the input predicate
+//
+// ok = false means no value satisfies the predicate
+// O(n)
+func (l *singlyLinkedList[T]) Find(predicate func(T) bool) (t T, ok bool) {
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if predicate(currNode.value) {
+			return currNode.value, true
+		}
+	}
+
+	return
+}
+
+// RemoveFirstMatch removes the first element equal to the input value
+//
+// ok = false means the value is not in the linked list
+// O(n)
+func (l *singlyLinkedList[T]) RemoveFirstMatch(t T) (ok bool) {
+	var previous *singlyNode[T]
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		if !l.equal(currNode.value, t) {
+			previous = currNode
+			continue
+		}
+
+		if previous == nil {
+			l.first = currNode.next
+		} else {
+			previous.next = currNode.next
+		}
+
+		if currNode.next == nil {
+			l.last = previous
+		}
+
+		// clear references to help garbage collection
+		currNode.next = nil
+
+		l.size -= 1
+		l.modCount += 1
+
+		return true
+	}
+
+	return
+}
+
+// RemoveAll removes all elements equal to the input value and returns the number of removed elements
+//
+// O(n)
+func (l *singlyLinkedList[T]) RemoveAll(t T) (removed int) {
+	return l.RemoveIf(func(value T) bool {
+		return l.equal(value, t)
+	})
+}
+
+// Sort sorts the linked list in place according to less
+//
+// the sort is stable and relinks the nodes with a bottom-up merge sort, without allocating
+// O(n*log(n))
+func (l *singlyLinkedList[T]) Sort(less comparator.Func[T]) {
+	if l.size < 2 {
+		return
+	}
+
+	// merge sorted runs of width nodes in pairs, doubling the width on each pass
+	for width := 1; width < l.size; width *= 2 {
+		var first, last *singlyNode[T]
+
+		rest := l.first
+		for rest != nil {
+			a := rest
+			b := splitSingly(a, width)
+			rest = splitSingly(b, width)
+
+			mergedFirst, mergedLast := mergeSingly(a, b, less)
+			if last == nil {
+				first = mergedFirst
+			} else {
+				last.next = mergedFirst
+			}
+			last = mergedLast
+		}
+
+		l.first = first
+		l.last = last
+	}
+
+	l.modCount += 1
+}
+
+// Reverse reverses the order of the elements of the linked list in place
+//
+// O(n)
+func (l *singlyLinkedList[T]) Reverse() {
+	if l.size < 2 {
+		return
+	}
+
+	var previous *singlyNode[T]
+
+	currNode := l.first
+	l.last = currNode
+	for currNode != nil {
+		next := currNode.next
+		currNode.next = previous
+
+		previous = currNode
+		currNode = next
+	}
+	l.first = previous
+
+	l.modCount += 1
+}
+
+// MergeSorted merges the elements of the other sorted linked list into this sorted linked list
+// according to less, leaving the other linked list empty
+//
+// the merge is stable, on ties the elements of this linked list come first
+// ok = false means other is nil, is this same linked list or is not a singly linked list
+// O(n+m)
+func (l *singlyLinkedList[T]) MergeSorted(other LinkedList[T], less comparator.Func[T]) (ok bool) {
+	o, isSingly := other.(*singlyLinkedList[T])
+	if !isSingly || o == nil || o == l {
+		return
+	}
+
+	if o.size == 0 {
+		return true
+	}
+
+	l.first, l.last = mergeSingly(l.first, o.first, less)
+	l.size += o.size
+	l.modCount += 1
+
+	o.first = nil
+	o.last = nil
+	o.size = 0
+	o.modCount += 1
+
+	return true
+}
+
+// Concat moves all elements of the other linked list to the end of this linked list,
+// leaving the other linked list empty
+//
+// ok = false means other is nil, is this same linked list or is not a singly linked list
+// O(1)
+func (l *singlyLinkedList[T]) Concat(other LinkedList[T]) (ok bool) {
+	return l.SpliceAt(l.size, other)
+}
+
+// SpliceAt moves all elements of the other linked list into this linked list, so that the first
+// of them is at the input index, leaving the other linked list empty
+//
+// index can be Size(), which moves the elements to the end of this linked list
+// ok = false means the index is out of range, or other is nil, is this same linked list or is
+// not a singly linked list
+// O(index), O(1) if index is 0 or Size()
+func (l *singlyLinkedList[T]) SpliceAt(index int, other LinkedList[T]) (ok bool) {
+	o, isSingly := other.(*singlyLinkedList[T])
+	if !isSingly || o == nil || o == l || index < 0 || index > l.size {
+		return
+	}
+
+	if o.size == 0 {
+		return true
+	}
+
+	size := o.size
+	first, last := o.cut(0, size)
+	l.insertChain(index, first, last, size)
+
+	return true
+}
+
+// SplitAt moves the elements from the input index to the end of the linked list into a new
+// singly linked list and returns it
+//
+// index can be Size(), which returns an empty linked list
+// returns nil if the index is out of range
+// O(index)
+func (l *singlyLinkedList[T]) SplitAt(index int) LinkedList[T] {
+	if index < 0 || index > l.size {
+		return nil
+	}
+
+	split := &singlyLinkedList[T]{equal: l.equal}
+	if index == l.size {
+		return split
+	}
+
+	size := l.size - index
+	first, last := l.cut(index, l.size)
+	split.insertChain(0, first, last, size)
+
+	return split
+}
+
+// MoveRange moves the elements in the index range [from, to) of the linked list to the end of dst
+//
+// ok = false means the range is out of range or reversed, or dst is nil, is this same
+// linked list or is not a singly linked list
+// O(to)
+func (l *singlyLinkedList[T]) MoveRange(from, to int, dst LinkedList[T]) (ok bool) {
+	d, isSingly := dst.(*singlyLinkedList[T])
+	if !isSingly || d == nil || d == l || from < 0 || to > l.size || from > to {
+		return
+	}
+
+	if from == to {
+		return true
+	}
+
+	first, last := l.cut(from, to)
+	d.insertChain(d.size, first, last, to-from)
+
+	return true
+}
+
+// cut detaches the nodes in the index range [from, to) from the linked list and returns
+// the first and last of them, the range should be valid and not empty
+//
+// O(to), O(from) if to is the size of the linked list
+func (l *singlyLinkedList[T]) cut(from, to int) (first, last *singlyNode[T]) {
+	var previous *singlyNode[T]
+	if from == 0 {
+		first = l.first
+	} else {
+		previous = l.get(from - 1)
+		first = previous.next
+	}
+
+	if to == l.size {
+		last = l.last
+	} else {
+		last = first
+		for i := from + 1; i < to; i++ {
+			last = last.next
+		}
+	}
+
+	next := last.next
+	last.next = nil
+
+	if previous == nil {
+		l.first = next
+	} else {
+		previous.next = next
+	}
+
+	if next == nil {
+		l.last = previous
+	}
+
+	l.size -= to - from
+	l.modCount += 1
+
+	return first, last
+}
+
+// insertChain links a chain of size nodes into the linked list, so that its first node
+// is at the input index, the index should be in range [0, size of the linked list]
+//
+// O(index), O(1) if index is 0 or the size of the linked list
+func (l *singlyLinkedList[T]) insertChain(index int, first, last *singlyNode[T], size int) {
+	if index == 0 {
+		last.next = l.first
+		l.first = first
+	} else if index == l.size {
+		l.last.next = first
+	} else {
+		previous := l.get(index - 1)
+
+		last.next = previous.next
+		previous.next = first
+	}
+
+	if last.next == nil {
+		l.last = last
+	}
+
+	l.size += size
+	l.modCount += 1
+}
+
+// splitSingly cuts the chain of nodes starting at the input node after n nodes
+// and returns the first node of the rest of the chain, nil if there is none
+func splitSingly[T any](node *singlyNode[T], n int) *singlyNode[T] {
+	for i := 1; node != nil && i < n; i++ {
+		node = node.next
+	}
+
+	if node == nil {
+		return nil
+	}
+
+	rest := node.next
+	node.next = nil
+
+	return rest
+}
+
+// mergeSingly merges two sorted chains of nodes and returns the first and last nodes of the merged chain
+//
+// on ties the nodes of a come first, returns nil nodes if both chains are empty
+func mergeSingly[T any](a, b *singlyNode[T], less comparator.Func[T]) (first, last *singlyNode[T]) {
+	// the chains are linked without a dummy head node, which would escape to the heap
+	for a != nil || b != nil {
+		var node *singlyNode[T]
+		if a == nil || (b != nil && less(b.value, a.value)) {
+			node = b
+			b = b.next
+		} else {
+			node = a
+			a = a.next
+		}
+
+		if last == nil {
+			first = node
+		} else {
+			last.next = node
+		}
+		last = node
+	}
+
+	return first, last
+}
+
+// All returns an iterator over the index-value pairs of the linked list,
+// from the first element to the last
+//
+// O(n)
+func (l *singlyLinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		modCount := l.modCount
+
+		index := 0
+		for currNode := l.first; currNode != nil; currNode = currNode.next {
+			if !yield(index, currNode.value) {
+				return
+			}
+			checkModification(modCount, l.modCount)
+			index++
+		}
+	}
+}
+
+// Values returns an iterator over the values of the linked list,
+// from the first element to the last
+//
+// O(n)
+func (l *singlyLinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		modCount := l.modCount
+
+		for currNode := l.first; currNode != nil; currNode = currNode.next {
+			if !yield(currNode.value) {
+				return
+			}
+			checkModification(modCount, l.modCount)
+		}
+	}
+}
+
+// ToSlice returns a new slice holding the values of the linked list,
+// from the first element to the last
+//
+// O(n)
+func (l *singlyLinkedList[T]) ToSlice() []T {
+	return l.AppendTo(make([]T, 0, l.size))
+}
+
+// AppendTo appends the values of the linked list to dst, from the first element
+// to the last, and returns the extended slice
+//
+// dst grows at most once
+// O(n)
+func (l *singlyLinkedList[T]) AppendTo(dst []T) []T {
+	dst = slices.Grow(dst, l.size)
+	for currNode := l.first; currNode != nil; currNode = currNode.next {
+		dst = append(dst, currNode.value)
+	}
+
+	return dst
+}
+
+// Backward returns an iterator over the index-value pairs of the linked list,
+// from the last element to the first
+//
+// since nodes only reference their next node, the nodes are first collected
+// into a slice, so the iteration costs O(n) time and O(n) extra memory
+func (l *singlyLinkedList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		modCount := l.modCount
+
+		nodes := make([]*singlyNode[T], 0, l.size)
+		for currNode := l.first; currNode != nil; currNode = currNode.next {
+			nodes = append(nodes, currNode)
+		}
+
+		for index := len(nodes) - 1; index >= 0; index-- {
+			if !yield(index, nodes[index].value) {
+				return
+			}
+			checkModification(modCount, l.modCount)
+		}
+	}
 }
 
 // NewSinglyLinkedList returns a new singly linked list
-func NewSinglyLinkedList[T any]() LinkedList[T] {
+//
+// Example usage:
+// - NewSinglyLinkedList[int]()
+// - NewSinglyLinkedList(WithEquality(func(a, b User) bool { return a.ID == b.ID }))
+func NewSinglyLinkedList[T any](opts ...Option[T]) LinkedList[T] {
+	o := newOptions(opts)
+
 	return &singlyLinkedList[T]{
 		first: nil,
 		last:  nil,
 		size:  0,
+		equal: o.equal,
 	}
 }
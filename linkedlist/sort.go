@@ -0,0 +1,23 @@
+package linkedlist
+
+import (
+	"cmp"
+	"github.com/TheFeij/go-collections/comparator"
+)
+
+// Sort sorts the linked list of an ordered type in ascending order, the same as
+// calling its Sort method with comparator.Less
+//
+// O(n*log(n))
+func Sort[T cmp.Ordered](l LinkedList[T]) {
+	l.Sort(comparator.Less[T])
+}
+
+// MergeSorted merges the elements of the other linked list into the linked list, both of an
+// ordered type sorted in ascending order, the same as calling its MergeSorted method with comparator.Less
+//
+// ok = false means other is nil, is this same linked list or is not of the same implementation.
+// O(n+m)
+func MergeSorted[T cmp.Ordered](l, other LinkedList[T]) (ok bool) {
+	return l.MergeSorted(other, comparator.Less[T])
+}
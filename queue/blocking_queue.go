@@ -0,0 +1,224 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by the operations of a BlockingQueue after it has been closed.
+var ErrClosed = errors.New("queue: blocking queue is closed")
+
+// blockingQueue is a struct representing a generic queue that is safe for concurrent use.
+type blockingQueue[T any] struct {
+	// mu guards all the fields below.
+	mu sync.Mutex
+
+	// queue is the underlying queue used to store the elements.
+	queue Queue[T]
+
+	// capacity is the maximum number of elements in the queue, 0 means unbounded.
+	capacity int
+
+	// closed reports whether Close has been called.
+	closed bool
+
+	// notEmpty holds the consumers waiting for an element, one of them is woken up
+	// whenever an element is added, and all of them when the queue is closed.
+	notEmpty waitList
+
+	// notFull holds the producers waiting for free space, one of them is woken up
+	// whenever an element is removed, and all of them when the queue is closed.
+	notFull waitList
+}
+
+// waitList is a FIFO list of goroutines waiting for a condition of a blocking queue,
+// each one waiting for its own channel to be closed
+//
+// unlike a sync.Cond, a waiter can stop waiting when its context is done
+// all methods should be called while holding the lock of the queue
+type waitList struct {
+	waiters []chan struct{}
+}
+
+// add adds a waiter to the end of the list and returns the channel it should wait for
+func (w *waitList) add() chan struct{} {
+	ch := make(chan struct{})
+	w.waiters = append(w.waiters, ch)
+
+	return ch
+}
+
+// remove removes the waiter of the input channel from the list
+//
+// if the waiter has already been woken up, the wakeup is passed on to the next waiter,
+// so it is not lost by a waiter that gives up
+func (w *waitList) remove(ch chan struct{}) {
+	index := slices.Index(w.waiters, ch)
+	if index < 0 {
+		w.signal()
+		return
+	}
+
+	w.waiters = slices.Delete(w.waiters, index, index+1)
+}
+
+// signal wakes up the first waiter, if any
+func (w *waitList) signal() {
+	if len(w.waiters) == 0 {
+		return
+	}
+
+	close(w.waiters[0])
+	w.waiters[0] = nil
+	w.waiters = w.waiters[1:]
+}
+
+// broadcast wakes up all the waiters
+func (w *waitList) broadcast() {
+	for _, ch := range w.waiters {
+		close(ch)
+	}
+
+	w.waiters = nil
+}
+
+// Put adds an element to the end of the queue, waiting for free space if the queue is full.
+//
+// It returns ctx.Err() if the context is done before the element is added,
+// or ErrClosed if the queue is closed.
+func (q *blockingQueue[T]) Put(ctx context.Context, t T) error {
+	q.mu.Lock()
+	for {
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+
+		if q.capacity == 0 || q.queue.Size() < q.capacity {
+			break
+		}
+
+		notFull := q.notFull.add()
+		q.mu.Unlock()
+
+		select {
+		case <-notFull:
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.notFull.remove(notFull)
+			q.mu.Unlock()
+
+			return ctx.Err()
+		}
+
+		q.mu.Lock()
+	}
+
+	q.queue.Enqueue(t)
+	q.notEmpty.signal()
+	q.mu.Unlock()
+
+	return nil
+}
+
+// Take removes and returns the element at the front of the queue, waiting for an
+// element if the queue is empty.
+//
+// It returns ctx.Err() if the context is done before an element is available,
+// or ErrClosed if the queue is closed and empty. Elements added before Close
+// can still be taken after the queue is closed.
+func (q *blockingQueue[T]) Take(ctx context.Context) (t T, err error) {
+	q.mu.Lock()
+	for q.queue.Size() == 0 {
+		if q.closed {
+			q.mu.Unlock()
+			return t, ErrClosed
+		}
+
+		notEmpty := q.notEmpty.add()
+		q.mu.Unlock()
+
+		select {
+		case <-notEmpty:
+		case <-ctx.Done():
+			q.mu.Lock()
+			q.notEmpty.remove(notEmpty)
+			q.mu.Unlock()
+
+			return t, ctx.Err()
+		}
+
+		q.mu.Lock()
+	}
+
+	t, _ = q.queue.Dequeue()
+	q.notFull.signal()
+	q.mu.Unlock()
+
+	return t, nil
+}
+
+// Offer adds an element to the end of the queue, waiting up to the given timeout
+// for free space if the queue is full.
+//
+// ok = false means the element was not added, either because the timeout
+// elapsed or because the queue is closed. A non-positive timeout does not wait.
+func (q *blockingQueue[T]) Offer(t T, timeout time.Duration) (ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return q.Put(ctx, t) == nil
+}
+
+// Poll removes and returns the element at the front of the queue, waiting up to
+// the given timeout for an element if the queue is empty.
+//
+// ok = false means no element was available before the timeout elapsed or
+// the queue is closed and empty. A non-positive timeout does not wait.
+func (q *blockingQueue[T]) Poll(timeout time.Duration) (t T, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	t, err := q.Take(ctx)
+	return t, err == nil
+}
+
+// Size returns the number of elements in the queue.
+func (q *blockingQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.queue.Size()
+}
+
+// Close closes the queue and wakes up all the waiting producers and consumers.
+//
+// After Close, Put and Offer fail, while Take and Poll keep returning the
+// remaining elements until the queue is empty. Calling Close more than once has no effect.
+func (q *blockingQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	q.closed = true
+	q.notEmpty.broadcast()
+	q.notFull.broadcast()
+}
+
+// NewBlockingQueue creates and returns a new queue that is safe for concurrent use.
+//
+// capacity bounds the number of elements in the queue, Put blocks while the queue
+// is full. A non-positive capacity means the queue is unbounded.
+// The elements are stored in a queue created by NewQueue.
+func NewBlockingQueue[T any](capacity int) BlockingQueue[T] {
+	return &blockingQueue[T]{
+		queue:    NewQueue[T](),
+		capacity: max(capacity, 0),
+	}
+}
@@ -0,0 +1,53 @@
+package stack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// jsonCodec is a Codec that encodes the elements with encoding/json.
+type jsonCodec[T any] struct{}
+
+// Encode returns the JSON encoding of the element.
+func (jsonCodec[T]) Encode(t T) ([]byte, error) {
+	return json.Marshal(t)
+}
+
+// Decode returns the element of a JSON encoding.
+func (jsonCodec[T]) Decode(data []byte) (t T, err error) {
+	err = json.Unmarshal(data, &t)
+	return
+}
+
+// gobCodec is a Codec that encodes the elements with encoding/gob.
+type gobCodec[T any] struct{}
+
+// Encode returns the gob encoding of the element, as a self-contained gob stream.
+func (gobCodec[T]) Encode(t T) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(t); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Decode returns the element of a gob stream written by Encode.
+func (gobCodec[T]) Decode(data []byte) (t T, err error) {
+	err = gob.NewDecoder(bytes.NewReader(data)).Decode(&t)
+	return
+}
+
+// JSONCodec returns a Codec that encodes the elements with encoding/json.
+func JSONCodec[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+// GobCodec returns a Codec that encodes the elements with encoding/gob.
+//
+// Every element is encoded as a self-contained gob stream, which repeats the type
+// information of T, so JSONCodec or a custom Codec is more compact for small elements.
+func GobCodec[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
@@ -0,0 +1,153 @@
+package stack
+
+import "github.com/TheFeij/go-collections/internal/serial"
+
+// The queues are serialized as the sequence of their elements, from the front to the end.
+
+// MarshalJSON encodes the queue as a JSON array of its elements, from the front to the end
+func (q *queue[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the queue with the elements of a JSON array, from the front to the end
+//
+// the queue is left unchanged if data is invalid or is the JSON null
+func (q *queue[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	q.replace(values)
+	return nil
+}
+
+// GobEncode encodes the queue as a gob stream of its elements, from the front to the end
+func (q *queue[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(q.ToSlice())
+}
+
+// GobDecode replaces the elements of the queue with the elements of a gob stream written by GobEncode
+//
+// the queue is left unchanged if data is invalid
+func (q *queue[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	q.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the queue in binary form, which is the same as GobEncode
+func (q *queue[T]) MarshalBinary() ([]byte, error) {
+	return q.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (q *queue[T]) UnmarshalBinary(data []byte) error {
+	return q.GobDecode(data)
+}
+
+// replace replaces the elements of the queue with the input values, enqueued in order
+//
+// O(n + m)
+func (q *queue[T]) replace(values []T) {
+	q.list.Clear()
+	for _, t := range values {
+		q.list.AddLast(t)
+	}
+}
+
+// MarshalJSON encodes the queue as a JSON array of its elements, from the front to the end
+func (q *ringQueue[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the queue with the elements of a JSON array, from the front to the end
+//
+// the queue is left unchanged if data is invalid or is the JSON null
+func (q *ringQueue[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	q.replace(values)
+	return nil
+}
+
+// GobEncode encodes the queue as a gob stream of its elements, from the front to the end
+func (q *ringQueue[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(q.ToSlice())
+}
+
+// GobDecode replaces the elements of the queue with the elements of a gob stream written by GobEncode
+//
+// the queue is left unchanged if data is invalid
+func (q *ringQueue[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	q.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the queue in binary form, which is the same as GobEncode
+func (q *ringQueue[T]) MarshalBinary() ([]byte, error) {
+	return q.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (q *ringQueue[T]) UnmarshalBinary(data []byte) error {
+	return q.GobDecode(data)
+}
+
+// MarshalJSON encodes the queue as a JSON array of its elements, from the front to the end
+func (q *persistentQueue[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the queue with the elements of a JSON array, from the front to the end
+//
+// the current elements are consumed and the new ones are appended to the log, so the replacement is persisted
+// the queue is left unchanged if data is invalid or is the JSON null
+func (q *persistentQueue[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	return q.replace(values)
+}
+
+// GobEncode encodes the queue as a gob stream of its elements, from the front to the end
+func (q *persistentQueue[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(q.ToSlice())
+}
+
+// GobDecode replaces the elements of the queue with the elements of a gob stream written by GobEncode
+//
+// the current elements are consumed and the new ones are appended to the log, so the replacement is persisted
+// the queue is left unchanged if data is invalid
+func (q *persistentQueue[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	return q.replace(values)
+}
+
+// MarshalBinary encodes the queue in binary form, which is the same as GobEncode
+func (q *persistentQueue[T]) MarshalBinary() ([]byte, error) {
+	return q.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (q *persistentQueue[T]) UnmarshalBinary(data []byte) error {
+	return q.GobDecode(data)
+}
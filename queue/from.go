@@ -0,0 +1,36 @@
+package stack
+
+import "iter"
+
+// FromSeq creates and returns a new queue holding the elements of the input sequence,
+// enqueued in order, so the first element of the sequence is at the front of the queue.
+//
+// The queue is implemented with a circular buffer, as the one returned by NewRingQueue.
+func FromSeq[T any](seq iter.Seq[T]) Queue[T] {
+	q := NewRingQueue[T](0)
+	for t := range seq {
+		q.Enqueue(t)
+	}
+
+	return q
+}
+
+// From creates and returns a new queue holding the input values, so the first value is at the front.
+//
+// The queue is implemented with a circular buffer, as the one returned by NewRingQueue.
+func From[T any](values ...T) Queue[T] {
+	return FromSlice(values)
+}
+
+// FromSlice creates and returns a new queue holding the elements of the input slice,
+// so the first element of the slice is at the front of the queue.
+//
+// The queue is implemented with a circular buffer, as the one returned by NewRingQueue.
+// The buffer is sized to hold the elements at once, but can still shrink down to the
+// default capacity as elements are dequeued. The slice is not referenced by the queue.
+func FromSlice[T any](s []T) Queue[T] {
+	q := &ringQueue[T]{minCapacity: defaultRingQueueCapacity}
+	q.replace(s)
+
+	return q
+}
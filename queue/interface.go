@@ -1,5 +1,14 @@
 package stack
 
+import (
+	"context"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"iter"
+	"time"
+)
+
 // Queue defines the interface for a generic queue data structure.
 type Queue[T any] interface {
 	// Enqueue adds an element to the end of the queue.
@@ -19,4 +28,95 @@ type Queue[T any] interface {
 
 	// Size returns the number of elements in the queue.
 	Size() int
+
+	// All returns an iterator over the elements of the queue, from the front to the end,
+	// without removing them.
+	All() iter.Seq[T]
+
+	// ToSlice returns a new slice holding the elements of the queue, from the front to the end.
+	ToSlice() []T
+
+	// AppendTo appends the elements of the queue to dst, from the front to the end,
+	// and returns the extended slice.
+	AppendTo(dst []T) []T
+
+	// The queue is serialized as the sequence of its elements, from the front to
+	// the end, and decoding replaces its elements.
+	json.Marshaler
+	json.Unmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// BlockingQueue defines the interface for a generic queue that is safe for concurrent use,
+// whose operations wait for an element to become available or for free space.
+type BlockingQueue[T any] interface {
+	// Put adds an element to the end of the queue, waiting for free space if the queue is full.
+	//
+	// It returns ctx.Err() if the context is done before the element is added,
+	// or ErrClosed if the queue is closed.
+	Put(ctx context.Context, t T) error
+
+	// Take removes and returns the element at the front of the queue, waiting for an
+	// element if the queue is empty.
+	//
+	// It returns ctx.Err() if the context is done before an element is available,
+	// or ErrClosed if the queue is closed and empty.
+	Take(ctx context.Context) (t T, err error)
+
+	// Offer adds an element to the end of the queue, waiting up to the given timeout
+	// for free space if the queue is full.
+	//
+	// ok = false means the element was not added.
+	Offer(t T, timeout time.Duration) (ok bool)
+
+	// Poll removes and returns the element at the front of the queue, waiting up to
+	// the given timeout for an element if the queue is empty.
+	//
+	// ok = false means no element was available.
+	Poll(timeout time.Duration) (t T, ok bool)
+
+	// Size returns the number of elements in the queue.
+	Size() int
+
+	// Close closes the queue and wakes up all the waiting producers and consumers.
+	Close()
+}
+
+// PersistentQueue defines the interface for a queue whose elements are stored on disk,
+// so they survive restarts and crashes of the process.
+//
+// The unconsumed elements are also kept in memory, so the queue uses O(n) memory for n
+// unconsumed elements and can not hold a backlog larger than the available memory.
+//
+// Enqueue and Dequeue can not return the errors of the underlying files, so the first
+// error is kept and reported by Err, after which the queue refuses further modifications:
+// Enqueue discards its element and Dequeue returns ok = false. Reopening the queue recovers it.
+type PersistentQueue[T any] interface {
+	Queue[T]
+
+	// Sync commits the enqueued elements, the consumed position and the creation and
+	// deletion of segment files to stable storage.
+	//
+	// It is only needed with SyncNever, the default SyncAlways commits every operation.
+	Sync() error
+
+	// Err returns the first error hit by the queue, ErrPersistentQueueClosed if the queue
+	// was closed without errors, or nil.
+	Err() error
+
+	// Close syncs the queue and closes its files. The elements stay readable through
+	// Peek, Size and All, but the queue can not be modified anymore.
+	Close() error
+}
+
+// Codec defines how the elements of a PersistentQueue are converted to and from bytes.
+type Codec[T any] interface {
+	// Encode returns the binary form of the element.
+	Encode(T) ([]byte, error)
+
+	// Decode returns the element of a binary form returned by Encode.
+	Decode([]byte) (T, error)
 }
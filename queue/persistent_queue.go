@@ -0,0 +1,788 @@
+package stack
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"iter"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrPersistentQueueClosed is reported by the Err method of a PersistentQueue after it has been closed.
+var ErrPersistentQueueClosed = errors.New("queue: persistent queue is closed")
+
+// ErrCorrupted is returned by NewPersistentQueue when the files of the queue are damaged in a way
+// that a crash during a write can not explain, since a crash only damages the tail of the last segment.
+var ErrCorrupted = errors.New("queue: persistent queue files are corrupted")
+
+const (
+	// segmentExtension is the file extension of the segment files, which are named by their zero padded id
+	segmentExtension = ".seg"
+
+	// cursorFile is the name of the file holding the position of the first unconsumed record
+	cursorFile = "head"
+
+	// cursorTempFile is the name of the file a new cursor is written to before it is renamed to cursorFile
+	cursorTempFile = "head.tmp"
+
+	// recordHeaderSize is the size of the header of a record: the length of the payload and the checksum
+	recordHeaderSize = 8
+
+	// cursorSlotSize is the size of a slot of the cursor file: the sequence number, the segment id,
+	// the offset and the checksum
+	cursorSlotSize = 28
+
+	// defaultSegmentSize is the segment size used when WithSegmentSize is not given a positive size
+	defaultSegmentSize = 16 << 20
+)
+
+// castagnoli is the CRC-32C table used for the checksums of the records and the cursor
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// SyncPolicy defines when a PersistentQueue commits its writes to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncAlways syncs every enqueued element and every consumed position before the
+	// operation returns, so no operation is lost, even if the machine crashes.
+	SyncAlways SyncPolicy = iota
+
+	// SyncNever leaves syncing to the operating system and to explicit Sync calls. No operation
+	// is lost if the process crashes, but the latest ones can be lost if the machine crashes.
+	// A full segment is still synced once when the queue moves on to the next one, so only
+	// the last segment and the cursor are left to Sync.
+	SyncNever
+)
+
+// PersistentOption configures a queue created by NewPersistentQueue.
+type PersistentOption func(*persistentOptions)
+
+// persistentOptions holds the configuration of a queue created by NewPersistentQueue.
+type persistentOptions struct {
+	sync        SyncPolicy
+	segmentSize int64
+}
+
+// WithSyncPolicy sets when the queue commits its writes to stable storage, SyncAlways by default.
+func WithSyncPolicy(policy SyncPolicy) PersistentOption {
+	return func(o *persistentOptions) {
+		o.sync = policy
+	}
+}
+
+// WithSegmentSize sets the size in bytes after which the queue starts a new segment file.
+//
+// Consumed segments are deleted, so the size bounds the disk space held by consumed elements.
+// If size is not positive, a default size of 16 MiB is used.
+func WithSegmentSize(size int64) PersistentOption {
+	return func(o *persistentOptions) {
+		o.segmentSize = size
+	}
+}
+
+// position locates a record in the log of a persistent queue
+type position struct {
+	// segment is the id of the segment file holding the record.
+	segment uint64
+
+	// offset is the offset of the record in its segment file.
+	offset int64
+}
+
+// persistentEntry is an element of a persistent queue, together with the position
+// right after its record, which becomes the head of the queue once it is dequeued
+type persistentEntry[T any] struct {
+	value T
+	next  position
+}
+
+// persistentQueue is a struct representing a generic queue data structure stored in
+// a write-ahead log of segment files, mirrored in memory.
+//
+// Enqueue appends a record to the last segment, the active one, and Dequeue moves the
+// head, stored in the cursor file, past the record of the dequeued element. Segments
+// that are entirely before the head are deleted.
+//
+// The cursor file has two slots that are overwritten in turn, each with a sequence number
+// and a checksum, so a torn write of the cursor leaves the previous head in the other slot.
+// The cursor file is created atomically with its first slot committed, so there is always
+// a previous head, even for the first write.
+type persistentQueue[T any] struct {
+	dir     string
+	codec   Codec[T]
+	options persistentOptions
+
+	// mirror holds the unconsumed elements, so reading the queue never touches the files.
+	mirror Queue[persistentEntry[T]]
+
+	// head is the position of the first unconsumed record, as written to the cursor file.
+	head position
+
+	// cursor is the cursor file, nil after Close.
+	cursor *os.File
+
+	// sequence is the sequence number of the last written cursor slot.
+	sequence uint64
+
+	// cursorDirty reports whether the cursor file was written without being synced.
+	cursorDirty bool
+
+	// first is the id of the oldest segment file.
+	first uint64
+
+	// active is the segment file that records are appended to, nil after Close.
+	active *os.File
+
+	// activeID is the id of the active segment.
+	activeID uint64
+
+	// activeSize is the size of the active segment file.
+	activeSize int64
+
+	// dirDirty reports whether segment files were created or deleted since the directory was last synced.
+	dirDirty bool
+
+	// err is the first error hit by the queue, after which the queue refuses modifications.
+	err error
+}
+
+// Size returns the number of elements in the queue.
+func (q *persistentQueue[T]) Size() int {
+	return q.mirror.Size()
+}
+
+// Enqueue appends the element to the log and adds it to the end of the queue.
+//
+// The element is discarded if the queue has failed or has been closed, or if it can not
+// be encoded or written, in which case the error is reported by Err.
+func (q *persistentQueue[T]) Enqueue(t T) {
+	if q.err != nil {
+		return
+	}
+
+	payload, err := q.codec.Encode(t)
+	if err != nil {
+		q.fail(fmt.Errorf("queue: encode element: %w", err))
+		return
+	}
+	if uint64(len(payload)) > math.MaxUint32 {
+		q.fail(fmt.Errorf("queue: encoded element of %d bytes is too large", len(payload)))
+		return
+	}
+
+	if q.activeSize >= q.options.segmentSize {
+		if err := q.roll(); err != nil {
+			q.fail(err)
+			return
+		}
+	}
+
+	record := make([]byte, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:], checksum(record[:4], payload))
+	copy(record[recordHeaderSize:], payload)
+
+	if _, err := q.active.Write(record); err != nil {
+		q.fail(err)
+		return
+	}
+	if q.options.sync == SyncAlways {
+		if err := q.active.Sync(); err != nil {
+			q.fail(err)
+			return
+		}
+	}
+
+	q.activeSize += int64(len(record))
+	q.mirror.Enqueue(persistentEntry[T]{
+		value: t,
+		next:  position{segment: q.activeID, offset: q.activeSize},
+	})
+}
+
+// Dequeue removes and returns the element at the front of the queue, moving the head of the log past it.
+//
+// It returns the front element and ok = true if the queue is not empty and has not
+// failed or been closed, otherwise it returns the zero value of type T and ok = false.
+// If the new head can not be written, the element is still returned and the error is
+// reported by Err, so the element is delivered again after the queue is reopened.
+func (q *persistentQueue[T]) Dequeue() (t T, ok bool) {
+	if q.err != nil {
+		return
+	}
+
+	entry, ok := q.mirror.Dequeue()
+	if !ok {
+		return
+	}
+
+	if err := q.moveHead(entry.next); err != nil {
+		q.fail(err)
+	}
+
+	return entry.value, true
+}
+
+// Peek returns the element at the front of the queue without removing it.
+//
+// It returns the front element and ok = true if the queue is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (q *persistentQueue[T]) Peek() (t T, ok bool) {
+	entry, ok := q.mirror.Peek()
+	return entry.value, ok
+}
+
+// All returns an iterator over the elements of the queue, from the front to the end.
+//
+// The queue should not be modified during the iteration.
+func (q *persistentQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for entry := range q.mirror.All() {
+			if !yield(entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a new slice holding the elements of the queue, from the front to the end.
+//
+// O(n)
+func (q *persistentQueue[T]) ToSlice() []T {
+	return q.AppendTo(make([]T, 0, q.mirror.Size()))
+}
+
+// AppendTo appends the elements of the queue to dst, from the front to the end,
+// and returns the extended slice.
+//
+// O(n)
+func (q *persistentQueue[T]) AppendTo(dst []T) []T {
+	dst = slices.Grow(dst, q.mirror.Size())
+	for entry := range q.mirror.All() {
+		dst = append(dst, entry.value)
+	}
+
+	return dst
+}
+
+// Sync commits the enqueued elements, the consumed position and the creation and
+// deletion of segment files to stable storage.
+func (q *persistentQueue[T]) Sync() error {
+	if q.err != nil {
+		return q.err
+	}
+
+	if err := q.active.Sync(); err != nil {
+		q.fail(err)
+		return err
+	}
+
+	if q.dirDirty {
+		if err := syncDir(q.dir); err != nil {
+			q.fail(err)
+			return err
+		}
+
+		q.dirDirty = false
+	}
+
+	if q.cursorDirty {
+		if err := q.cursor.Sync(); err != nil {
+			q.fail(err)
+			return err
+		}
+
+		q.cursorDirty = false
+	}
+
+	return nil
+}
+
+// Err returns the first error hit by the queue, ErrPersistentQueueClosed if the queue
+// was closed without errors, or nil.
+func (q *persistentQueue[T]) Err() error {
+	return q.err
+}
+
+// Close syncs the queue and closes its files, returning the errors of the sync and of each close.
+//
+// The files are closed even if the queue has failed before, for example with no active segment
+// after a failed roll. Closing a closed queue does nothing.
+func (q *persistentQueue[T]) Close() error {
+	if q.active == nil && q.cursor == nil {
+		return nil
+	}
+
+	var syncErr, activeErr, cursorErr error
+	if q.err == nil {
+		syncErr = q.Sync()
+	}
+
+	if q.active != nil {
+		activeErr = q.active.Close()
+	}
+	if q.cursor != nil {
+		cursorErr = q.cursor.Close()
+	}
+
+	q.active = nil
+	q.cursor = nil
+	q.fail(ErrPersistentQueueClosed)
+
+	return errors.Join(syncErr, activeErr, cursorErr)
+}
+
+// replace replaces the elements of the queue with the input values, enqueued in order
+//
+// the current elements are consumed by moving the head to the end of the log
+func (q *persistentQueue[T]) replace(values []T) error {
+	if q.err != nil {
+		return q.err
+	}
+
+	q.mirror = NewRingQueue[persistentEntry[T]](0)
+	if err := q.moveHead(position{segment: q.activeID, offset: q.activeSize}); err != nil {
+		q.fail(err)
+		return err
+	}
+
+	for _, t := range values {
+		q.Enqueue(t)
+	}
+
+	return q.err
+}
+
+// fail keeps the input error as the error of the queue, unless the queue has already failed
+func (q *persistentQueue[T]) fail(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// moveHead writes the input position as the new head of the queue and deletes the
+// segments that are entirely before it
+func (q *persistentQueue[T]) moveHead(head position) error {
+	if err := q.writeCursor(head, q.options.sync == SyncAlways); err != nil {
+		return err
+	}
+
+	q.head = head
+
+	return q.compact()
+}
+
+// compact deletes the segments that are entirely before the head of the queue
+func (q *persistentQueue[T]) compact() error {
+	for ; q.first < q.head.segment; q.first++ {
+		if err := os.Remove(q.segmentPath(q.first)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		q.dirDirty = true
+	}
+
+	return nil
+}
+
+// roll syncs and closes the active segment and starts the next one
+//
+// a closed segment is never synced again, so it is synced here whatever the sync policy,
+// otherwise Sync could return while the records of the earlier segments are not durable
+func (q *persistentQueue[T]) roll() error {
+	if err := q.active.Sync(); err != nil {
+		return err
+	}
+	if err := q.active.Close(); err != nil {
+		return err
+	}
+
+	q.active = nil
+	return q.openSegment(q.activeID+1, 0)
+}
+
+// openSegment opens the segment with the input id and size for appending, creating it if needed,
+// and makes it the active segment
+func (q *persistentQueue[T]) openSegment(id uint64, size int64) error {
+	file, err := os.OpenFile(q.segmentPath(id), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	q.active = file
+	q.activeID = id
+	q.activeSize = size
+
+	if q.options.sync == SyncAlways {
+		// make the new directory entry durable
+		return syncDir(q.dir)
+	}
+
+	q.dirDirty = true
+
+	return nil
+}
+
+// writeCursor writes the input head to the slot of the cursor file after the last written one
+//
+// the cursor is synced if sync is true, otherwise it is marked dirty
+func (q *persistentQueue[T]) writeCursor(head position, sync bool) error {
+	sequence := q.sequence + 1
+
+	slot := cursorSlot(sequence, head)
+	if _, err := q.cursor.WriteAt(slot[:], int64(sequence%2)*cursorSlotSize); err != nil {
+		return err
+	}
+	q.sequence = sequence
+
+	q.cursorDirty = !sync
+	if sync {
+		return q.cursor.Sync()
+	}
+
+	return nil
+}
+
+// createCursor creates the cursor file with the input head committed in its first slot, with sequence
+// number 0, and opens it
+//
+// the file is written to a temporary file, synced and renamed, so the cursor file either does not exist
+// or has a valid slot, and a torn write of the next slot can always fall back to this one
+func (q *persistentQueue[T]) createCursor(head position) error {
+	temp := filepath.Join(q.dir, cursorTempFile)
+	file, err := os.OpenFile(temp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	slot := cursorSlot(0, head)
+	_, err = file.Write(slot[:])
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(temp, filepath.Join(q.dir, cursorFile)); err != nil {
+		return err
+	}
+	if err := syncDir(q.dir); err != nil {
+		return err
+	}
+
+	q.cursor, err = os.OpenFile(filepath.Join(q.dir, cursorFile), os.O_RDWR, 0)
+	q.sequence = 0
+	q.cursorDirty = false
+
+	return err
+}
+
+// readCursor reads the head from the valid slot of the cursor file with the highest sequence number
+//
+// returns found = false if the cursor file is missing or empty, which only happens before
+// the cursor is created, since createCursor never leaves an empty cursor file
+func (q *persistentQueue[T]) readCursor() (head position, sequence uint64, found bool, err error) {
+	cursor, err := os.ReadFile(filepath.Join(q.dir, cursorFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return head, 0, false, nil
+	}
+	if err != nil || len(cursor) == 0 {
+		return head, 0, false, err
+	}
+
+	for offset := 0; offset+cursorSlotSize <= len(cursor); offset += cursorSlotSize {
+		slot := cursor[offset : offset+cursorSlotSize]
+		if crc32.Checksum(slot[:24], castagnoli) != binary.LittleEndian.Uint32(slot[24:]) {
+			continue
+		}
+
+		if slotSequence := binary.LittleEndian.Uint64(slot); !found || slotSequence > sequence {
+			sequence = slotSequence
+			head.segment = binary.LittleEndian.Uint64(slot[8:])
+			head.offset = int64(binary.LittleEndian.Uint64(slot[16:]))
+			found = true
+		}
+	}
+
+	if !found {
+		return head, 0, false, fmt.Errorf("%w: damaged cursor file", ErrCorrupted)
+	}
+
+	return head, sequence, true, nil
+}
+
+// cursorSlot encodes the input sequence number and head into a slot of the cursor file
+func cursorSlot(sequence uint64, head position) (slot [cursorSlotSize]byte) {
+	binary.LittleEndian.PutUint64(slot[:], sequence)
+	binary.LittleEndian.PutUint64(slot[8:], head.segment)
+	binary.LittleEndian.PutUint64(slot[16:], uint64(head.offset))
+	binary.LittleEndian.PutUint32(slot[24:], crc32.Checksum(slot[:24], castagnoli))
+
+	return slot
+}
+
+// segments returns the ids of the segment files in increasing order
+func (q *persistentQueue[T]) segments() ([]uint64, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), segmentExtension)
+		if !ok || !entry.Type().IsRegular() {
+			continue
+		}
+
+		id, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		ids = append(ids, id)
+	}
+
+	slices.Sort(ids)
+
+	return ids, nil
+}
+
+// segmentPath returns the path of the segment file with the input id
+func (q *persistentQueue[T]) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, segmentExtension))
+}
+
+// recover rebuilds the queue from its files after it was closed or after a crash
+//
+// the unconsumed records of every segment are decoded into the mirror. A damaged record
+// at the tail of the last segment is the trace of a crash during a write, so the segment
+// is truncated before it. Consumed segments left by an interrupted compaction are deleted.
+func (q *persistentQueue[T]) recover() error {
+	// a leftover temporary cursor was never renamed, so it is not the current cursor
+	if err := os.Remove(filepath.Join(q.dir, cursorTempFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	ids, err := q.segments()
+	if err != nil {
+		return err
+	}
+
+	head, sequence, found, err := q.readCursor()
+	if err != nil {
+		return err
+	}
+
+	if found {
+		q.cursor, err = os.OpenFile(filepath.Join(q.dir, cursorFile), os.O_RDWR, 0)
+		if err != nil {
+			return err
+		}
+		q.sequence = sequence
+	} else {
+		// the first segment is unconsumed, and the cursor is created before any data is accepted
+		if len(ids) > 0 {
+			head = position{segment: ids[0], offset: 0}
+		}
+
+		if err := q.createCursor(head); err != nil {
+			return err
+		}
+	}
+
+	q.head = head
+	if len(ids) > 0 {
+		q.first = ids[0]
+		if err := q.compact(); err != nil {
+			return err
+		}
+	}
+	ids = slices.DeleteFunc(ids, func(id uint64) bool {
+		return id < head.segment
+	})
+
+	// the head segment has been deleted by hand, the queue restarts from the next segment
+	if len(ids) > 0 && ids[0] != head.segment {
+		head = position{segment: ids[0], offset: 0}
+	}
+	if len(ids) == 0 {
+		ids = []uint64{head.segment}
+	}
+	q.first = ids[0]
+
+	for i, id := range ids {
+		data, err := os.ReadFile(q.segmentPath(id))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		offset := int64(0)
+		if id == head.segment {
+			// the head can be past the end of its segment when the records were lost
+			// by a crash of the machine while the cursor was synced
+			head.offset = min(head.offset, int64(len(data)))
+			offset = head.offset
+		}
+
+		last := i == len(ids)-1
+		for offset < int64(len(data)) {
+			payload, size, ok := parseRecord(data[offset:])
+			if !ok {
+				if !last {
+					return fmt.Errorf("%w: damaged record at offset %d of segment %d", ErrCorrupted, offset, id)
+				}
+
+				if err := os.Truncate(q.segmentPath(id), offset); err != nil {
+					return err
+				}
+				data = data[:offset]
+
+				break
+			}
+
+			t, err := q.codec.Decode(payload)
+			if err != nil {
+				return fmt.Errorf("queue: decode element at offset %d of segment %d: %w", offset, id, err)
+			}
+
+			offset += size
+			q.mirror.Enqueue(persistentEntry[T]{
+				value: t,
+				next:  position{segment: id, offset: offset},
+			})
+		}
+
+		if last {
+			if err := q.openSegment(id, int64(len(data))); err != nil {
+				return err
+			}
+		}
+	}
+
+	// the truncation and the new segment are committed before the queue is used
+	if q.options.sync == SyncAlways {
+		if err := q.active.Sync(); err != nil {
+			return err
+		}
+	}
+
+	if head != q.head {
+		q.head = head
+		return q.writeCursor(head, q.options.sync == SyncAlways)
+	}
+
+	return nil
+}
+
+// parseRecord parses the record at the start of data and returns its payload and its size
+//
+// returns ok = false if the record is incomplete or its checksum does not match
+func parseRecord(data []byte) (payload []byte, size int64, ok bool) {
+	if len(data) < recordHeaderSize {
+		return
+	}
+
+	length := binary.LittleEndian.Uint32(data)
+	if uint64(length) > uint64(len(data)-recordHeaderSize) {
+		return
+	}
+
+	payload = data[recordHeaderSize : recordHeaderSize+int(length)]
+	if checksum(data[:4], payload) != binary.LittleEndian.Uint32(data[4:]) {
+		return nil, 0, false
+	}
+
+	return payload, recordHeaderSize + int64(length), true
+}
+
+// checksum returns the checksum of a record, which covers its length, so a zero filled
+// tail left by a crash is not mistaken for empty records
+func checksum(length, payload []byte) uint32 {
+	return crc32.Update(crc32.Checksum(length, castagnoli), castagnoli, payload)
+}
+
+// syncDir syncs the directory, making the creation, deletion and renaming of its files durable
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	err = file.Sync()
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// NewPersistentQueue opens the persistent queue stored in the input directory, creating the
+// directory if needed, and recovers its elements.
+//
+// The elements are stored in an append-only log of segment files, encoded with the given codec,
+// and the position of the front element is stored in a cursor file, so no element is lost or
+// delivered twice across restarts. After a crash, a record that was being written is dropped,
+// and an element whose dequeue was not committed yet is delivered again.
+//
+// Memory: every unconsumed element is also kept in memory, decoded, so reading the queue does not
+// touch the files. The queue therefore uses O(n) memory for n unconsumed elements, as much as an
+// in-memory queue, and opening it decodes the whole backlog. It protects the backlog from crashes
+// and restarts, but it does not hold backlogs larger than the available memory.
+//
+// The queue is not safe for concurrent use, and a directory should be opened by a single queue at a time.
+//
+// Example usage:
+// - NewPersistentQueue("/var/lib/app/queue", JSONCodec[Job]())
+// - NewPersistentQueue(dir, GobCodec[Job](), WithSyncPolicy(SyncNever), WithSegmentSize(1<<20))
+func NewPersistentQueue[T any](dir string, codec Codec[T], opts ...PersistentOption) (PersistentQueue[T], error) {
+	if codec == nil {
+		return nil, errors.New("queue: codec is nil")
+	}
+
+	o := persistentOptions{
+		sync:        SyncAlways,
+		segmentSize: defaultSegmentSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.segmentSize <= 0 {
+		o.segmentSize = defaultSegmentSize
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &persistentQueue[T]{
+		dir:     dir,
+		codec:   codec,
+		options: o,
+		mirror:  NewRingQueue[persistentEntry[T]](0),
+	}
+
+	if err := q.recover(); err != nil {
+		if q.active != nil {
+			q.active.Close()
+		}
+		if q.cursor != nil {
+			q.cursor.Close()
+		}
+
+		return nil, err
+	}
+
+	return q, nil
+}
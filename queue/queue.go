@@ -1,6 +1,9 @@
 package stack
 
-import "github.com/TheFeij/go-collections/linkedlist"
+import (
+	"github.com/TheFeij/go-collections/linkedlist"
+	"iter"
+)
 
 // queue is a struct representing a generic queue data structure.
 type queue[T any] struct {
@@ -41,6 +44,28 @@ func (q *queue[T]) Peek() (t T, ok bool) {
 	return
 }
 
+// All returns an iterator over the elements of the queue, from the front to the end.
+//
+// The iterator panics if the queue is modified during the iteration.
+func (q *queue[T]) All() iter.Seq[T] {
+	return q.list.Values()
+}
+
+// ToSlice returns a new slice holding the elements of the queue, from the front to the end.
+//
+// O(n)
+func (q *queue[T]) ToSlice() []T {
+	return q.list.ToSlice()
+}
+
+// AppendTo appends the elements of the queue to dst, from the front to the end,
+// and returns the extended slice.
+//
+// O(n)
+func (q *queue[T]) AppendTo(dst []T) []T {
+	return q.list.AppendTo(dst)
+}
+
 // NewQueue creates and returns a new queue.
 // It initializes the underlying linked list with a new singly linked list.
 func NewQueue[T any]() Queue[T] {
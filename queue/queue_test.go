@@ -1,78 +1,1145 @@
 package stack
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"github.com/stretchr/testify/require"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 )
 
+// queues is the list of queue implementations that the tests run against
+var queues = []struct {
+	name     string
+	newQueue func(t *testing.T) Queue[any]
+}{
+	{
+		name: "linked list queue",
+		newQueue: func(t *testing.T) Queue[any] {
+			return NewQueue[any]()
+		},
+	},
+	{
+		name: "ring queue",
+		newQueue: func(t *testing.T) Queue[any] {
+			return NewRingQueue[any](1)
+		},
+	},
+	{
+		name: "persistent queue",
+		newQueue: func(t *testing.T) Queue[any] {
+			// small segments, so the behavior tests also roll and compact segments
+			q, err := NewPersistentQueue(t.TempDir(), JSONCodec[any](), WithSyncPolicy(SyncNever), WithSegmentSize(64))
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, q.Close())
+			})
+
+			return q
+		},
+	},
+}
+
 func TestNewQueue(t *testing.T) {
-	q := NewQueue[any]()
+	for _, queue := range queues {
+		t.Run(queue.name, func(t *testing.T) {
+			q := queue.newQueue(t)
 
-	require.NotNil(t, q)
-	require.NotEmpty(t, q)
-	require.Equal(t, 0, q.Size())
+			require.NotNil(t, q)
+			require.NotEmpty(t, q)
+			require.Equal(t, 0, q.Size())
 
-	// no value to peep
-	value, ok := q.Peek()
-	require.Zero(t, value)
-	require.False(t, ok)
+			// no value to peep
+			value, ok := q.Peek()
+			require.Zero(t, value)
+			require.False(t, ok)
 
-	// no value to dequeue
-	value, ok = q.Dequeue()
-	require.Zero(t, value)
-	require.False(t, ok)
+			// no value to dequeue
+			value, ok = q.Dequeue()
+			require.Zero(t, value)
+			require.False(t, ok)
+		})
+	}
 }
 
 func TestQueue_Enqueue_Dequeue(t *testing.T) {
-	q := NewQueue[any]()
+	for _, queue := range queues {
+		t.Run(queue.name, func(t *testing.T) {
+			q := queue.newQueue(t)
+
+			const numberOfElements = 5
+			for i := 0; i < numberOfElements; i++ {
+				q.Enqueue(i)
+				require.Equal(t, i+1, q.Size())
+			}
+
+			require.Equal(t, numberOfElements, q.Size())
+
+			for i := 0; i < numberOfElements; i++ {
+				value, ok := q.Dequeue()
+				require.True(t, ok)
+				require.Equal(t, i, value)
+				require.Equal(t, numberOfElements-i-1, q.Size())
+			}
+
+			value, ok := q.Dequeue()
+			require.False(t, ok)
+			require.Zero(t, value)
+			require.Equal(t, 0, q.Size())
+		})
+	}
+}
+
+func TestQueue_Peek(t *testing.T) {
+	for _, queue := range queues {
+		t.Run(queue.name, func(t *testing.T) {
+			q := queue.newQueue(t)
+
+			value, ok := q.Peek()
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			const numberOfElements = 5
+			for i := 0; i < numberOfElements; i++ {
+				q.Enqueue(i)
+
+				value, ok = q.Peek()
+				require.True(t, ok)
+				require.Equal(t, 0, value)
+			}
+
+			for i := 0; i < numberOfElements; i++ {
+				value, ok = q.Peek()
+				require.True(t, ok)
+				require.Equal(t, i, value)
+
+				_, ok := q.Dequeue()
+				require.True(t, ok)
+			}
+
+			value, ok = q.Peek()
+			require.False(t, ok)
+			require.Zero(t, value)
+		})
+	}
+}
+
+func TestQueue_Interleaved(t *testing.T) {
+	for _, queue := range queues {
+		t.Run(queue.name, func(t *testing.T) {
+			q := queue.newQueue(t)
+
+			// enqueue two elements and dequeue one in each round so that
+			// the front of the queue keeps moving while the queue grows
+			next, expected := 0, 0
+			for round := 0; round < 1000; round++ {
+				q.Enqueue(next)
+				q.Enqueue(next + 1)
+				next += 2
+
+				value, ok := q.Dequeue()
+				require.True(t, ok)
+				require.Equal(t, expected, value)
+				expected++
+			}
+			require.Equal(t, 1000, q.Size())
+
+			for q.Size() > 0 {
+				value, ok := q.Dequeue()
+				require.True(t, ok)
+				require.Equal(t, expected, value)
+				expected++
+			}
+			require.Equal(t, next, expected)
+		})
+	}
+}
+
+func TestRingQueue_Capacity(t *testing.T) {
+	t.Run("Non Positive Capacity", func(t *testing.T) {
+		q := NewRingQueue[int](0).(*ringQueue[int])
+		require.Equal(t, defaultRingQueueCapacity, len(q.data))
+
+		q = NewRingQueue[int](-1).(*ringQueue[int])
+		require.Equal(t, defaultRingQueueCapacity, len(q.data))
+	})
+	t.Run("Grow And Shrink", func(t *testing.T) {
+		q := NewRingQueue[int](4).(*ringQueue[int])
+
+		for i := 0; i < 100; i++ {
+			q.Enqueue(i)
+		}
+		require.Equal(t, 128, len(q.data))
+
+		for i := 0; i < 100; i++ {
+			value, ok := q.Dequeue()
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+
+		// the buffer shrinks back but never below the initial capacity
+		require.Equal(t, 4, len(q.data))
+	})
+}
+
+func TestBlockingQueue_Put_Take(t *testing.T) {
+	q := NewBlockingQueue[int](0)
+	ctx := context.Background()
 
 	const numberOfElements = 5
 	for i := 0; i < numberOfElements; i++ {
-		q.Enqueue(i)
+		require.NoError(t, q.Put(ctx, i))
 		require.Equal(t, i+1, q.Size())
 	}
 
-	require.Equal(t, numberOfElements, q.Size())
-
 	for i := 0; i < numberOfElements; i++ {
-		value, ok := q.Dequeue()
-		require.True(t, ok)
+		value, err := q.Take(ctx)
+		require.NoError(t, err)
 		require.Equal(t, i, value)
-		require.Equal(t, numberOfElements-i-1, q.Size())
 	}
-
-	value, ok := q.Dequeue()
-	require.False(t, ok)
-	require.Zero(t, value)
 	require.Equal(t, 0, q.Size())
 }
 
-func TestQueue_Peek(t *testing.T) {
-	q := NewQueue[any]()
+func TestBlockingQueue_Context(t *testing.T) {
+	t.Run("Take From Empty Queue", func(t *testing.T) {
+		q := NewBlockingQueue[int](0)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		value, err := q.Take(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Zero(t, value)
+	})
+	t.Run("Put To Full Queue", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		require.NoError(t, q.Put(context.Background(), 1))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := q.Put(ctx, 2)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, q.Size())
+	})
+}
+
+func TestBlockingQueue_WaitList(t *testing.T) {
+	var w waitList
+	first, second, third := w.add(), w.add(), w.add()
+
+	// a signal wakes up the first waiter only
+	w.signal()
+	require.True(t, isClosed(first))
+	require.False(t, isClosed(second))
+
+	// a waiter that gives up before being woken up leaves the others waiting
+	w.remove(third)
+	require.False(t, isClosed(second))
+
+	// a woken up waiter that gives up passes the wakeup on
+	w.remove(first)
+	require.True(t, isClosed(second))
+	require.Empty(t, w.waiters)
+
+	// nothing to wake up
+	w.signal()
+
+	fourth, fifth := w.add(), w.add()
+	w.broadcast()
+	require.True(t, isClosed(fourth))
+	require.True(t, isClosed(fifth))
+	require.Empty(t, w.waiters)
+}
+
+// isClosed reports whether the input channel is closed, without blocking
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBlockingQueue_Offer_Poll(t *testing.T) {
+	q := NewBlockingQueue[int](2)
 
-	value, ok := q.Peek()
+	require.True(t, q.Offer(1, 0))
+	require.True(t, q.Offer(2, time.Millisecond))
+	require.False(t, q.Offer(3, 0))
+	require.False(t, q.Offer(3, 10*time.Millisecond))
+	require.Equal(t, 2, q.Size())
+
+	value, ok := q.Poll(0)
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	value, ok = q.Poll(time.Millisecond)
+	require.True(t, ok)
+	require.Equal(t, 2, value)
+
+	value, ok = q.Poll(10 * time.Millisecond)
 	require.False(t, ok)
 	require.Zero(t, value)
+}
 
-	const numberOfElements = 5
-	for i := 0; i < numberOfElements; i++ {
-		q.Enqueue(i)
+func TestBlockingQueue_Backpressure(t *testing.T) {
+	q := NewBlockingQueue[int](1)
+	ctx := context.Background()
+	require.NoError(t, q.Put(ctx, 1))
+
+	done := make(chan error)
+	go func() {
+		done <- q.Put(ctx, 2)
+	}()
+
+	select {
+	case <-done:
+		require.Fail(t, "Put should block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	value, err := q.Take(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, value)
+
+	require.NoError(t, <-done)
+
+	value, err = q.Take(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	t.Run("Wakes Up Waiters", func(t *testing.T) {
+		q := NewBlockingQueue[int](0)
+
+		const numberOfConsumers = 10
+		errs := make(chan error, numberOfConsumers)
+		for i := 0; i < numberOfConsumers; i++ {
+			go func() {
+				_, err := q.Take(context.Background())
+				errs <- err
+			}()
+		}
+
+		q.Close()
+		for i := 0; i < numberOfConsumers; i++ {
+			require.ErrorIs(t, <-errs, ErrClosed)
+		}
+	})
+	t.Run("Wakes Up Producers", func(t *testing.T) {
+		q := NewBlockingQueue[int](1)
+		require.NoError(t, q.Put(context.Background(), 1))
 
-		value, ok = q.Peek()
+		done := make(chan error)
+		go func() {
+			done <- q.Put(context.Background(), 2)
+		}()
+
+		q.Close()
+		require.ErrorIs(t, <-done, ErrClosed)
+	})
+	t.Run("Drains Remaining Elements", func(t *testing.T) {
+		q := NewBlockingQueue[int](0)
+		ctx := context.Background()
+
+		require.NoError(t, q.Put(ctx, 1))
+		require.NoError(t, q.Put(ctx, 2))
+
+		q.Close()
+		q.Close()
+
+		require.ErrorIs(t, q.Put(ctx, 3), ErrClosed)
+		require.False(t, q.Offer(3, time.Millisecond))
+
+		value, err := q.Take(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, value)
+
+		value, ok := q.Poll(time.Millisecond)
 		require.True(t, ok)
-		require.Equal(t, 0, value)
+		require.Equal(t, 2, value)
+
+		_, err = q.Take(ctx)
+		require.ErrorIs(t, err, ErrClosed)
+	})
+}
+
+func TestBlockingQueue_Concurrent(t *testing.T) {
+	const (
+		numberOfProducers   = 8
+		numberOfConsumers   = 8
+		elementsPerProducer = 1000
+	)
+
+	q := NewBlockingQueue[int](16)
+	ctx := context.Background()
+
+	// the goroutines only record their results, the checks are made on the test goroutine
+	var producers sync.WaitGroup
+	putErrs := make([]error, numberOfProducers)
+	for p := 0; p < numberOfProducers; p++ {
+		producers.Add(1)
+		go func(p int) {
+			defer producers.Done()
+			for i := 0; i < elementsPerProducer; i++ {
+				if err := q.Put(ctx, p*elementsPerProducer+i); err != nil {
+					putErrs[p] = err
+					return
+				}
+			}
+		}(p)
 	}
 
-	for i := 0; i < numberOfElements; i++ {
-		value, ok = q.Peek()
+	var consumers sync.WaitGroup
+	taken := make([][]int, numberOfConsumers)
+	takeErrs := make([]error, numberOfConsumers)
+	for c := 0; c < numberOfConsumers; c++ {
+		consumers.Add(1)
+		go func(c int) {
+			defer consumers.Done()
+			for {
+				value, err := q.Take(ctx)
+				if err != nil {
+					takeErrs[c] = err
+					return
+				}
+				taken[c] = append(taken[c], value)
+			}
+		}(c)
+	}
+
+	producers.Wait()
+	q.Close()
+	consumers.Wait()
+
+	for _, err := range putErrs {
+		require.NoError(t, err)
+	}
+	// the consumers only stop once the queue is closed and drained
+	for _, err := range takeErrs {
+		require.ErrorIs(t, err, ErrClosed)
+	}
+
+	seen := make(map[int]bool)
+	for _, values := range taken {
+		for _, value := range values {
+			require.False(t, seen[value])
+			seen[value] = true
+		}
+	}
+	require.Len(t, seen, numberOfProducers*elementsPerProducer)
+}
+
+func BenchmarkQueue(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		newQueue func() Queue[int]
+	}{
+		{name: "linked list queue", newQueue: NewQueue[int]},
+		{name: "ring queue", newQueue: func() Queue[int] { return NewRingQueue[int](0) }},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name+"/Enqueue Dequeue", func(b *testing.B) {
+			q := benchmark.newQueue()
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				q.Enqueue(i)
+				q.Dequeue()
+			}
+		})
+		b.Run(benchmark.name+"/Enqueue All Then Dequeue All", func(b *testing.B) {
+			const batchSize = 1024
+			q := benchmark.newQueue()
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < batchSize; j++ {
+					q.Enqueue(j)
+				}
+				for j := 0; j < batchSize; j++ {
+					q.Dequeue()
+				}
+			}
+		})
+	}
+}
+
+func TestQueue_All(t *testing.T) {
+	for _, queue := range queues {
+		t.Run(queue.name, func(t *testing.T) {
+			q := queue.newQueue(t)
+			require.Empty(t, slices.Collect(q.All()))
+
+			// dequeue a few elements first, so the ring queue wraps around its buffer
+			for i := 0; i < 6; i++ {
+				q.Enqueue(i)
+			}
+			for i := 0; i < 3; i++ {
+				q.Dequeue()
+			}
+			for i := 6; i < 8; i++ {
+				q.Enqueue(i)
+			}
+
+			require.Equal(t, []any{3, 4, 5, 6, 7}, slices.Collect(q.All()))
+			require.Equal(t, 5, q.Size())
+
+			var values []any
+			for value := range q.All() {
+				if len(values) == 2 {
+					break
+				}
+				values = append(values, value)
+			}
+			require.Equal(t, []any{3, 4}, values)
+		})
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	q := FromSeq(slices.Values([]int{1, 2, 3}))
+	require.Equal(t, 3, q.Size())
+	require.Equal(t, []int{1, 2, 3}, slices.Collect(q.All()))
+
+	value, ok := q.Dequeue()
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	require.Zero(t, FromSeq(slices.Values([]int{})).Size())
+}
+
+func TestQueue_ToSlice(t *testing.T) {
+	for _, queue := range queues {
+		t.Run(queue.name, func(t *testing.T) {
+			q := queue.newQueue(t)
+			require.Equal(t, []any{}, q.ToSlice())
+			require.Nil(t, q.AppendTo(nil))
+
+			// dequeue a few elements first and refill, so the ring queue wraps around its buffer
+			for i := 0; i < 8; i++ {
+				q.Enqueue(i)
+			}
+			for i := 0; i < 3; i++ {
+				q.Dequeue()
+			}
+			for i := 8; i < 11; i++ {
+				q.Enqueue(i)
+			}
+
+			expected := []any{3, 4, 5, 6, 7, 8, 9, 10}
+			require.Equal(t, expected, q.ToSlice())
+			require.Equal(t, append([]any{"a"}, expected...), q.AppendTo([]any{"a"}))
+			require.Equal(t, len(expected), q.Size())
+		})
+	}
+}
+
+func TestFrom_FromSlice(t *testing.T) {
+	q := From(1, 2, 3)
+	require.Equal(t, []int{1, 2, 3}, q.ToSlice())
+
+	value, ok := q.Dequeue()
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	// the input slice is copied
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	q = FromSlice(data)
+	data[0] = -1
+	require.Equal(t, 100, q.Size())
+
+	for i := 0; i < 100; i++ {
+		value, ok := q.Dequeue()
 		require.True(t, ok)
 		require.Equal(t, i, value)
+	}
+	require.Zero(t, q.Size())
+
+	// the queue keeps working after it shrank
+	q.Enqueue(1)
+	require.Equal(t, []int{1}, q.ToSlice())
+
+	require.Zero(t, From[int]().Size())
+	require.Zero(t, FromSlice[int](nil).Size())
+}
+
+func TestQueue_Encoding(t *testing.T) {
+	constructors := []struct {
+		name     string
+		newQueue func() Queue[int]
+	}{
+		{
+			name:     "linked list queue",
+			newQueue: NewQueue[int],
+		},
+		{
+			name: "ring queue",
+			newQueue: func() Queue[int] {
+				return NewRingQueue[int](4)
+			},
+		},
+	}
+
+	for _, constructor := range constructors {
+		t.Run(constructor.name, func(t *testing.T) {
+			// dequeue a few elements first and refill, so the ring queue wraps around its buffer
+			q := constructor.newQueue()
+			for i := 0; i < 4; i++ {
+				q.Enqueue(i)
+			}
+			q.Dequeue()
+			q.Enqueue(4)
+
+			data, err := json.Marshal(q)
+			require.NoError(t, err)
+			require.JSONEq(t, `[1, 2, 3, 4]`, string(data))
+
+			decoded := constructor.newQueue()
+			decoded.Enqueue(100)
+			require.NoError(t, json.Unmarshal(data, decoded))
+			require.Equal(t, []int{1, 2, 3, 4}, decoded.ToSlice())
+
+			// null and invalid data leave the queue unchanged
+			require.NoError(t, json.Unmarshal([]byte(`null`), decoded))
+			require.Error(t, json.Unmarshal([]byte(`[1, "a"]`), decoded))
+			require.Equal(t, []int{1, 2, 3, 4}, decoded.ToSlice())
+
+			var buffer bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buffer).Encode(q))
+			decoded = constructor.newQueue()
+			require.NoError(t, gob.NewDecoder(&buffer).Decode(decoded))
+			require.Equal(t, []int{1, 2, 3, 4}, decoded.ToSlice())
+
+			binary, err := q.MarshalBinary()
+			require.NoError(t, err)
+			decoded = constructor.newQueue()
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			require.Equal(t, []int{1, 2, 3, 4}, decoded.ToSlice())
+			require.Error(t, decoded.UnmarshalBinary([]byte("invalid")))
+			require.Equal(t, []int{1, 2, 3, 4}, decoded.ToSlice())
+
+			// the decoded queue keeps working
+			for i := 5; i < 20; i++ {
+				decoded.Enqueue(i)
+			}
+			for i := 1; i < 20; i++ {
+				value, ok := decoded.Dequeue()
+				require.True(t, ok)
+				require.Equal(t, i, value)
+			}
+
+			// an empty queue encodes to an empty one
+			require.NoError(t, json.Unmarshal([]byte(`[]`), q))
+			require.Zero(t, q.Size())
+			q.Enqueue(1)
+			require.Equal(t, []int{1}, q.ToSlice())
+		})
+	}
+}
+
+// crash abandons a persistent queue without syncing or closing it, as a crash of the process would,
+// only releasing its file handles
+func crash[T any](q PersistentQueue[T]) {
+	pq := q.(*persistentQueue[T])
+	if pq.active != nil {
+		pq.active.Close()
+	}
+	if pq.cursor != nil {
+		pq.cursor.Close()
+	}
+}
+
+// segmentFiles returns the paths of the segment files of the persistent queue in dir, in order
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+segmentExtension))
+	require.NoError(t, err)
+
+	return paths
+}
+
+// openPersistentQueue opens the persistent queue of ints in dir, failing the test on error
+func openPersistentQueue(t *testing.T, dir string, opts ...PersistentOption) PersistentQueue[int] {
+	t.Helper()
+
+	q, err := NewPersistentQueue(dir, JSONCodec[int](), opts...)
+	require.NoError(t, err)
+	require.NoError(t, q.Err())
+
+	return q
+}
+
+// failingCodec is a codec of ints that fails to encode negative numbers
+type failingCodec struct {
+	Codec[int]
+}
+
+func (c failingCodec) Encode(t int) ([]byte, error) {
+	if t < 0 {
+		return nil, errors.New("negative number")
+	}
+
+	return c.Codec.Encode(t)
+}
+
+func TestPersistentQueue(t *testing.T) {
+	t.Run("Reopen", func(t *testing.T) {
+		for _, policy := range []SyncPolicy{SyncAlways, SyncNever} {
+			dir := t.TempDir()
+
+			q := openPersistentQueue(t, dir, WithSyncPolicy(policy))
+			_, ok := q.Peek()
+			require.False(t, ok)
+
+			for i := 0; i < 10; i++ {
+				q.Enqueue(i)
+			}
+			for i := 0; i < 4; i++ {
+				value, ok := q.Dequeue()
+				require.True(t, ok)
+				require.Equal(t, i, value)
+			}
+			require.Equal(t, 6, q.Size())
+			require.NoError(t, q.Close())
+			require.NoError(t, q.Close())
+
+			// the dequeued elements stay consumed
+			q = openPersistentQueue(t, dir, WithSyncPolicy(policy))
+			require.Equal(t, []int{4, 5, 6, 7, 8, 9}, q.ToSlice())
+			require.Equal(t, []int{4, 5, 6, 7, 8, 9}, slices.Collect(q.All()))
+
+			value, ok := q.Peek()
+			require.True(t, ok)
+			require.Equal(t, 4, value)
+
+			q.Enqueue(10)
+			require.NoError(t, q.Sync())
+			require.NoError(t, q.Close())
+
+			q = openPersistentQueue(t, dir, WithSyncPolicy(policy))
+			require.Equal(t, []int{4, 5, 6, 7, 8, 9, 10}, q.ToSlice())
+			require.NoError(t, q.Close())
+		}
+	})
+
+	t.Run("Crash", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir)
+		for i := 0; i < 5; i++ {
+			q.Enqueue(i)
+		}
+		q.Dequeue()
+		crash(q)
+
+		// nothing acknowledged is lost
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{1, 2, 3, 4}, q.ToSlice())
+		crash(q)
+	})
+
+	t.Run("Crash After Sync Across Segments", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir, WithSyncPolicy(SyncNever), WithSegmentSize(64))
+		for i := 0; i < 100; i++ {
+			q.Enqueue(i)
+		}
+		for i := 0; i < 10; i++ {
+			q.Dequeue()
+		}
+		require.Greater(t, len(segmentFiles(t, dir)), 5)
+
+		// Sync commits the full segments, the directory entries and the cursor, not only the active segment
+		require.NoError(t, q.Sync())
+		pq := q.(*persistentQueue[int])
+		require.False(t, pq.dirDirty)
+		require.False(t, pq.cursorDirty)
+		crash(q)
+
+		q = openPersistentQueue(t, dir, WithSyncPolicy(SyncNever), WithSegmentSize(64))
+		expected := make([]int, 0, 90)
+		for i := 10; i < 100; i++ {
+			expected = append(expected, i)
+		}
+		require.Equal(t, expected, q.ToSlice())
+		require.NoError(t, q.Close())
+	})
+
+	t.Run("Torn Tail", func(t *testing.T) {
+		damages := []struct {
+			name   string
+			damage func(data []byte) []byte
+			kept   int
+		}{
+			{
+				name: "Truncated Payload",
+				damage: func(data []byte) []byte {
+					return data[:len(data)-1]
+				},
+				kept: 4,
+			},
+			{
+				name: "Truncated Header",
+				damage: func(data []byte) []byte {
+					return append(data, 1, 0, 0)
+				},
+				kept: 5,
+			},
+			{
+				name: "Checksum Mismatch",
+				damage: func(data []byte) []byte {
+					data[len(data)-1] ^= 0xff
+					return data
+				},
+				kept: 4,
+			},
+			{
+				name: "Zero Filled Tail",
+				damage: func(data []byte) []byte {
+					return append(data, make([]byte, 64)...)
+				},
+				kept: 5,
+			},
+		}
+
+		for _, damage := range damages {
+			t.Run(damage.name, func(t *testing.T) {
+				dir := t.TempDir()
+
+				q := openPersistentQueue(t, dir)
+				for i := 0; i < 5; i++ {
+					q.Enqueue(i)
+				}
+				crash(q)
+
+				paths := segmentFiles(t, dir)
+				require.Len(t, paths, 1)
+
+				data, err := os.ReadFile(paths[0])
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(paths[0], damage.damage(data), 0o644))
+
+				// the damaged record is dropped and the segment is truncated before it
+				q = openPersistentQueue(t, dir)
+				expected := []int{0, 1, 2, 3, 4}[:damage.kept]
+				require.Equal(t, expected, q.ToSlice())
+
+				q.Enqueue(5)
+				crash(q)
+
+				q = openPersistentQueue(t, dir)
+				require.Equal(t, append(expected, 5), q.ToSlice())
+				require.NoError(t, q.Close())
+			})
+		}
+	})
 
+	t.Run("Torn Cursor Write", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir)
+		for i := 0; i < 5; i++ {
+			q.Enqueue(i)
+		}
+		q.Dequeue()
+		q.Dequeue()
+		sequence := q.(*persistentQueue[int]).sequence
+		crash(q)
+
+		// damage the slot of the last written cursor
+		cursor, err := os.OpenFile(filepath.Join(dir, cursorFile), os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = cursor.WriteAt([]byte{0xff, 0xff}, int64(sequence%2)*cursorSlotSize+10)
+		require.NoError(t, err)
+		require.NoError(t, cursor.Close())
+
+		// the previous head is used, so the last dequeued element is delivered again
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{1, 2, 3, 4}, q.ToSlice())
+
+		value, ok := q.Dequeue()
+		require.True(t, ok)
+		require.Equal(t, 1, value)
+		require.NoError(t, q.Close())
+
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{2, 3, 4}, q.ToSlice())
+		require.NoError(t, q.Close())
+	})
+
+	t.Run("Torn First Cursor Write", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir)
+		for i := 0; i < 3; i++ {
+			q.Enqueue(i)
+		}
+		q.Dequeue()
+		require.Equal(t, uint64(1), q.(*persistentQueue[int]).sequence)
+		crash(q)
+
+		// the first write goes to the second slot, tearing it leaves the slot committed at creation
+		require.NoError(t, os.Truncate(filepath.Join(dir, cursorFile), 40))
+
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{0, 1, 2}, q.ToSlice())
+		require.NoError(t, q.Close())
+
+		// a temporary cursor that was never renamed is ignored
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cursorTempFile), []byte("partial"), 0o644))
+
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{0, 1, 2}, q.ToSlice())
+		require.NoFileExists(t, filepath.Join(dir, cursorTempFile))
+		require.NoError(t, q.Close())
+	})
+
+	t.Run("Segments and Compaction", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir, WithSegmentSize(64))
+		for i := 0; i < 100; i++ {
+			q.Enqueue(i)
+		}
+		segments := len(segmentFiles(t, dir))
+		require.Greater(t, segments, 10)
+
+		// consumed segments are deleted as the head leaves them
+		for i := 0; i < 50; i++ {
+			value, ok := q.Dequeue()
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+		require.Less(t, len(segmentFiles(t, dir)), segments)
+		require.NoError(t, q.Close())
+
+		q = openPersistentQueue(t, dir, WithSegmentSize(64))
+		require.Equal(t, 50, q.Size())
+		for i := 50; i < 100; i++ {
+			value, ok := q.Dequeue()
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+		require.Len(t, segmentFiles(t, dir), 1)
+
+		q.Enqueue(100)
+		require.NoError(t, q.Close())
+
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{100}, q.ToSlice())
+		require.NoError(t, q.Close())
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir, WithSegmentSize(16))
+		for i := 0; i < 10; i++ {
+			q.Enqueue(i)
+		}
+		require.NoError(t, q.Close())
+
+		// only the tail of the last segment can be damaged by a crash
+		paths := segmentFiles(t, dir)
+		require.Greater(t, len(paths), 2)
+		require.NoError(t, os.Truncate(paths[1], 3))
+
+		_, err := NewPersistentQueue(dir, JSONCodec[int]())
+		require.ErrorIs(t, err, ErrCorrupted)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cursorFile), []byte("invalid"), 0o644))
+		_, err = NewPersistentQueue(dir, JSONCodec[int]())
+		require.ErrorIs(t, err, ErrCorrupted)
+
+		// elements that the codec can not decode
+		dir = t.TempDir()
+		q = openPersistentQueue(t, dir)
+		q.Enqueue(1)
+		require.NoError(t, q.Close())
+
+		_, err = NewPersistentQueue(dir, JSONCodec[string]())
+		require.Error(t, err)
+
+		_, err = NewPersistentQueue[int](dir, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q, err := NewPersistentQueue[int](dir, failingCodec{JSONCodec[int]()})
+		require.NoError(t, err)
+
+		q.Enqueue(1)
+		q.Enqueue(-1)
+		require.ErrorContains(t, q.Err(), "negative number")
+
+		// a failed queue refuses modifications
+		q.Enqueue(2)
 		_, ok := q.Dequeue()
+		require.False(t, ok)
+		require.Equal(t, []int{1}, q.ToSlice())
+		require.Error(t, q.Sync())
+		require.NoError(t, q.Close())
+
+		// reopening recovers the queue
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{1}, q.ToSlice())
+		require.NoError(t, q.Close())
+		require.ErrorIs(t, q.Err(), ErrPersistentQueueClosed)
+
+		// a closed queue stays readable
+		q.Enqueue(2)
+		_, ok = q.Dequeue()
+		require.False(t, ok)
+		value, ok := q.Peek()
 		require.True(t, ok)
-	}
+		require.Equal(t, 1, value)
+	})
 
-	value, ok = q.Peek()
-	require.False(t, ok)
-	require.Zero(t, value)
+	t.Run("Close After Failed Roll", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir, WithSegmentSize(1))
+		q.Enqueue(1)
+
+		// a directory in place of the next segment makes the roll fail, leaving no active segment
+		require.NoError(t, os.Mkdir(q.(*persistentQueue[int]).segmentPath(1), 0o755))
+		q.Enqueue(2)
+		require.Error(t, q.Err())
+		require.Nil(t, q.(*persistentQueue[int]).active)
+
+		// the cursor file is still released
+		cursor := q.(*persistentQueue[int]).cursor
+		require.NoError(t, q.Close())
+		require.Nil(t, q.(*persistentQueue[int]).cursor)
+		require.ErrorIs(t, cursor.Close(), os.ErrClosed)
+		require.NoError(t, q.Close())
+
+		value, ok := q.Peek()
+		require.True(t, ok)
+		require.Equal(t, 1, value)
+	})
+
+	t.Run("Encoding", func(t *testing.T) {
+		dir := t.TempDir()
+
+		q := openPersistentQueue(t, dir)
+		q.Enqueue(1)
+		q.Enqueue(2)
+
+		data, err := json.Marshal(q)
+		require.NoError(t, err)
+		require.JSONEq(t, `[1, 2]`, string(data))
+
+		// the replacement is persisted
+		require.NoError(t, json.Unmarshal([]byte(`[3, 4, 5]`), q))
+		require.Equal(t, []int{3, 4, 5}, q.ToSlice())
+		require.NoError(t, q.Close())
+
+		q = openPersistentQueue(t, dir)
+		require.Equal(t, []int{3, 4, 5}, q.ToSlice())
+
+		binary, err := q.MarshalBinary()
+		require.NoError(t, err)
+
+		other := openPersistentQueue(t, t.TempDir(), WithSyncPolicy(SyncNever))
+		require.NoError(t, other.UnmarshalBinary(binary))
+		require.Equal(t, []int{3, 4, 5}, other.ToSlice())
+		require.NoError(t, other.Close())
+		require.ErrorIs(t, other.UnmarshalBinary(binary), ErrPersistentQueueClosed)
+		require.NoError(t, q.Close())
+	})
+
+	t.Run("Randomized", func(t *testing.T) {
+		dir := t.TempDir()
+		random := rand.New(rand.NewSource(1))
+
+		q := openPersistentQueue(t, dir, WithSegmentSize(32), WithSyncPolicy(SyncNever))
+		var model []int
+
+		for i := 0; i < 2000; i++ {
+			switch operation := random.Intn(10); {
+			case operation < 5:
+				q.Enqueue(i)
+				model = append(model, i)
+			case operation < 9:
+				value, ok := q.Dequeue()
+				require.Equal(t, len(model) > 0, ok)
+				if ok {
+					require.Equal(t, model[0], value)
+					model = model[1:]
+				}
+			default:
+				// a crash of the process loses nothing, even without syncing
+				crash(q)
+				q = openPersistentQueue(t, dir, WithSegmentSize(32), WithSyncPolicy(SyncNever))
+			}
+
+			require.Equal(t, len(model), q.Size())
+		}
+
+		require.Equal(t, model, q.ToSlice())
+		require.NoError(t, q.Close())
+	})
+
+	t.Run("Gob Codec", func(t *testing.T) {
+		type job struct {
+			ID   int
+			Name string
+		}
+
+		dir := t.TempDir()
+
+		q, err := NewPersistentQueue(dir, GobCodec[job]())
+		require.NoError(t, err)
+		q.Enqueue(job{ID: 1, Name: "a"})
+		q.Enqueue(job{ID: 2, Name: "b"})
+		require.NoError(t, q.Close())
+
+		q, err = NewPersistentQueue(dir, GobCodec[job]())
+		require.NoError(t, err)
+		require.Equal(t, []job{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, q.ToSlice())
+		require.NoError(t, q.Close())
+	})
+}
+
+func BenchmarkPersistentQueue(b *testing.B) {
+	for _, policy := range []struct {
+		name   string
+		policy SyncPolicy
+	}{
+		{name: "Sync Always", policy: SyncAlways},
+		{name: "Sync Never", policy: SyncNever},
+	} {
+		b.Run(policy.name, func(b *testing.B) {
+			q, err := NewPersistentQueue(b.TempDir(), JSONCodec[int](), WithSyncPolicy(policy.policy))
+			require.NoError(b, err)
+			defer q.Close()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				q.Enqueue(i)
+				q.Dequeue()
+			}
+		})
+	}
 }
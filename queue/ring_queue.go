@@ -0,0 +1,163 @@
+package stack
+
+import "iter"
+
+// defaultRingQueueCapacity is the capacity used by NewRingQueue when a non-positive capacity is given
+const defaultRingQueueCapacity = 16
+
+// ringQueue is a struct representing a generic queue data structure
+// implemented with a growable circular buffer.
+type ringQueue[T any] struct {
+	// data is the underlying circular buffer, its length is the capacity of the queue.
+	data []T
+
+	// head is the index of the front element in data.
+	head int
+
+	// size is the number of elements in the queue.
+	size int
+
+	// minCapacity is the capacity that the buffer never shrinks below.
+	minCapacity int
+}
+
+// Size returns the number of elements in the queue.
+func (q *ringQueue[T]) Size() int {
+	return q.size
+}
+
+// Enqueue adds an element to the end of the queue.
+//
+// amortized O(1), the buffer doubles its capacity when it is full.
+func (q *ringQueue[T]) Enqueue(t T) {
+	if q.size == len(q.data) {
+		q.resize(2 * len(q.data))
+	}
+
+	q.data[(q.head+q.size)%len(q.data)] = t
+	q.size += 1
+}
+
+// Dequeue removes and returns the element at the front of the queue.
+//
+// It returns the front element and ok = true if the queue is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+//
+// amortized O(1), the buffer halves its capacity when it is only a quarter full.
+func (q *ringQueue[T]) Dequeue() (t T, ok bool) {
+	if q.size == 0 {
+		return
+	}
+
+	var zero T
+	t = q.data[q.head]
+
+	// clear the reference to help garbage collection
+	q.data[q.head] = zero
+
+	q.head = (q.head + 1) % len(q.data)
+	q.size -= 1
+
+	if half := len(q.data) / 2; q.size <= len(q.data)/4 && half >= q.minCapacity {
+		q.resize(half)
+	}
+
+	return t, true
+}
+
+// Peek returns the element at the front of the queue without removing it.
+//
+// It returns the front element and ok = true if the queue is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (q *ringQueue[T]) Peek() (t T, ok bool) {
+	if q.size == 0 {
+		return
+	}
+
+	return q.data[q.head], true
+}
+
+// All returns an iterator over the elements of the queue, from the front to the end.
+//
+// The queue should not be modified during the iteration.
+func (q *ringQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < q.size; i++ {
+			if !yield(q.data[(q.head+i)%len(q.data)]) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a new slice holding the elements of the queue, from the front to the end.
+//
+// O(n)
+func (q *ringQueue[T]) ToSlice() []T {
+	return q.AppendTo(make([]T, 0, q.size))
+}
+
+// AppendTo appends the elements of the queue to dst, from the front to the end,
+// and returns the extended slice.
+//
+// O(n)
+func (q *ringQueue[T]) AppendTo(dst []T) []T {
+	// the elements are at most two contiguous runs of the buffer
+	if q.head+q.size <= len(q.data) {
+		return append(dst, q.data[q.head:q.head+q.size]...)
+	}
+
+	dst = append(dst, q.data[q.head:]...)
+	return append(dst, q.data[:q.head+q.size-len(q.data)]...)
+}
+
+// resize moves the elements of the queue into a new buffer with the given capacity
+//
+// the capacity should not be less than the size of the queue, should be checked at the caller
+// O(n)
+func (q *ringQueue[T]) resize(capacity int) {
+	data := make([]T, capacity)
+
+	// copy the elements in order, the front element is placed at index 0
+	if q.head+q.size <= len(q.data) {
+		copy(data, q.data[q.head:q.head+q.size])
+	} else {
+		n := copy(data, q.data[q.head:])
+		copy(data[n:], q.data[:q.size-n])
+	}
+
+	q.data = data
+	q.head = 0
+}
+
+// replace replaces the elements of the queue with the input values, enqueued in order
+//
+// the values are copied into a new buffer sized to hold them, but not smaller than the
+// minimum capacity, and the slice is not referenced by the queue
+// O(m)
+func (q *ringQueue[T]) replace(values []T) {
+	q.data = make([]T, max(len(values), q.minCapacity))
+	copy(q.data, values)
+
+	q.head = 0
+	q.size = len(values)
+}
+
+// NewRingQueue creates and returns a new queue backed by a circular buffer
+// with the given initial capacity.
+//
+// The buffer grows when it is full and shrinks when it is mostly empty,
+// but never below the initial capacity. If capacity is not positive,
+// a default capacity is used.
+func NewRingQueue[T any](capacity int) Queue[T] {
+	if capacity <= 0 {
+		capacity = defaultRingQueueCapacity
+	}
+
+	return &ringQueue[T]{
+		data:        make([]T, capacity),
+		head:        0,
+		size:        0,
+		minCapacity: capacity,
+	}
+}
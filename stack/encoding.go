@@ -0,0 +1,116 @@
+package stack
+
+import "github.com/TheFeij/go-collections/internal/serial"
+
+// The stacks are serialized as the sequence of their elements in push order, the same order
+// as ToSlice, so decoding pushes the elements back in that order and restores the same top.
+
+// MarshalJSON encodes the stack as a JSON array of its elements, from the bottom to the top
+func (s *stack[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the stack with the elements of a JSON array, from the bottom to the top
+//
+// the stack is left unchanged if data is invalid or is the JSON null
+func (s *stack[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	s.replace(values)
+	return nil
+}
+
+// GobEncode encodes the stack as a gob stream of its elements, from the bottom to the top
+func (s *stack[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(s.ToSlice())
+}
+
+// GobDecode replaces the elements of the stack with the elements of a gob stream written by GobEncode
+//
+// the stack is left unchanged if data is invalid
+func (s *stack[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the stack in binary form, which is the same as GobEncode
+func (s *stack[T]) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (s *stack[T]) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// replace replaces the elements of the stack with the input values, pushed in order
+//
+// O(n + m)
+func (s *stack[T]) replace(values []T) {
+	s.list.Clear()
+	for _, t := range values {
+		s.list.AddFirst(t)
+	}
+}
+
+// MarshalJSON encodes the stack as a JSON array of its elements, from the bottom to the top
+func (s *sliceStack[T]) MarshalJSON() ([]byte, error) {
+	return serial.MarshalJSON(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the stack with the elements of a JSON array, from the bottom to the top
+//
+// the stack is left unchanged if data is invalid or is the JSON null
+func (s *sliceStack[T]) UnmarshalJSON(data []byte) error {
+	values, err := serial.UnmarshalJSON[T](data)
+	if err != nil || values == nil {
+		return err
+	}
+
+	s.replace(values)
+	return nil
+}
+
+// GobEncode encodes the stack as a gob stream of its elements, from the bottom to the top
+func (s *sliceStack[T]) GobEncode() ([]byte, error) {
+	return serial.EncodeGob(s.ToSlice())
+}
+
+// GobDecode replaces the elements of the stack with the elements of a gob stream written by GobEncode
+//
+// the stack is left unchanged if data is invalid
+func (s *sliceStack[T]) GobDecode(data []byte) error {
+	values, err := serial.DecodeGob[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.replace(values)
+	return nil
+}
+
+// MarshalBinary encodes the stack in binary form, which is the same as GobEncode
+func (s *sliceStack[T]) MarshalBinary() ([]byte, error) {
+	return s.GobEncode()
+}
+
+// UnmarshalBinary decodes the binary form written by MarshalBinary, as GobDecode
+func (s *sliceStack[T]) UnmarshalBinary(data []byte) error {
+	return s.GobDecode(data)
+}
+
+// replace replaces the elements of the stack with the input values, pushed in order
+//
+// the stack takes the ownership of the values slice
+// O(1)
+func (s *sliceStack[T]) replace(values []T) {
+	s.data = values
+}
@@ -0,0 +1,35 @@
+package stack
+
+import "iter"
+
+// FromSeq creates and returns a new stack holding the elements of the input sequence,
+// pushed in order, so the last element of the sequence is at the top of the stack.
+//
+// The stack is implemented with a slice, as the one returned by NewSliceStack.
+func FromSeq[T any](seq iter.Seq[T]) SliceStack[T] {
+	s := NewSliceStack[T]()
+	for t := range seq {
+		s.Push(t)
+	}
+
+	return s
+}
+
+// From creates and returns a new stack holding the input values, pushed in order,
+// so the last value is at the top of the stack.
+//
+// The stack is implemented with a slice, as the one returned by NewSliceStack.
+func From[T any](values ...T) SliceStack[T] {
+	return FromSlice(values)
+}
+
+// FromSlice creates and returns a new stack holding the elements of the input slice,
+// pushed in order, so the last element of the slice is at the top of the stack.
+//
+// The stack is implemented with a slice, as the one returned by NewSliceStack.
+// The slice is copied and not referenced by the stack.
+func FromSlice[T any](s []T) SliceStack[T] {
+	return &sliceStack[T]{
+		data: append(make([]T, 0, len(s)), s...),
+	}
+}
@@ -1,5 +1,12 @@
 package stack
 
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"iter"
+)
+
 // Stack defines the interface for a generic stack data structure.
 type Stack[T any] interface {
 	// Push adds an element to the top of the stack.
@@ -19,4 +26,41 @@ type Stack[T any] interface {
 
 	// Size returns the number of elements in the stack.
 	Size() int
+
+	// All returns an iterator over the elements of the stack, from the top to the bottom,
+	// without removing them.
+	All() iter.Seq[T]
+
+	// ToSlice returns a new slice holding the elements of the stack in push order,
+	// from the bottom to the top, so FromSlice(s.ToSlice()) recreates the stack.
+	ToSlice() []T
+
+	// AppendTo appends the elements of the stack to dst in push order, from the
+	// bottom to the top, and returns the extended slice.
+	AppendTo(dst []T) []T
+
+	// The stack is serialized as the sequence of its elements in push order, from
+	// the bottom to the top, and decoding replaces its elements.
+	json.Marshaler
+	json.Unmarshaler
+	gob.GobEncoder
+	gob.GobDecoder
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+// SliceStack defines the interface for a stack implemented with a slice,
+// which in addition to the Stack methods provides control over its capacity.
+type SliceStack[T any] interface {
+	Stack[T]
+
+	// Cap returns the number of elements the stack can hold without reallocating.
+	Cap() int
+
+	// Grow grows the capacity of the stack, if necessary, to guarantee space for
+	// another n elements.
+	Grow(n int)
+
+	// Clip releases the unused capacity of the stack.
+	Clip()
 }
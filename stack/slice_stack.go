@@ -0,0 +1,148 @@
+package stack
+
+import "iter"
+
+// sliceStack is a struct representing a generic stack data structure implemented with a slice.
+type sliceStack[T any] struct {
+	// data is the underlying slice used to implement the stack, the top element is the last one.
+	data []T
+}
+
+// Size returns the number of elements in the stack.
+func (s *sliceStack[T]) Size() int {
+	return len(s.data)
+}
+
+// Push adds an element to the top of the stack.
+//
+// amortized O(1)
+func (s *sliceStack[T]) Push(t T) {
+	s.data = append(s.data, t)
+}
+
+// Pop removes and returns the top element from the stack.
+//
+// It returns the popped element and ok = true if the stack is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (s *sliceStack[T]) Pop() (t T, ok bool) {
+	size := len(s.data)
+	if size == 0 {
+		return
+	}
+
+	var zero T
+	t = s.data[size-1]
+
+	// clear the reference to help garbage collection
+	s.data[size-1] = zero
+	s.data = s.data[:size-1]
+
+	return t, true
+}
+
+// Peek returns the top element from the stack without removing it.
+//
+// It returns the top element and ok = true if the stack is not empty,
+// otherwise it returns the zero value of type T and ok = false.
+func (s *sliceStack[T]) Peek() (t T, ok bool) {
+	size := len(s.data)
+	if size == 0 {
+		return
+	}
+
+	return s.data[size-1], true
+}
+
+// Cap returns the number of elements the stack can hold without reallocating.
+func (s *sliceStack[T]) Cap() int {
+	return cap(s.data)
+}
+
+// Grow grows the capacity of the stack, if necessary, to guarantee space for
+// another n elements. Grow does nothing if n is not positive.
+//
+// O(n) if the stack has to reallocate, otherwise O(1)
+func (s *sliceStack[T]) Grow(n int) {
+	if n <= 0 || cap(s.data)-len(s.data) >= n {
+		return
+	}
+
+	data := make([]T, len(s.data), len(s.data)+n)
+	copy(data, s.data)
+
+	s.data = data
+}
+
+// Clip releases the unused capacity of the stack by moving its elements
+// into a slice whose capacity equals the number of elements.
+//
+// O(n)
+func (s *sliceStack[T]) Clip() {
+	if cap(s.data) == len(s.data) {
+		return
+	}
+
+	data := make([]T, len(s.data))
+	copy(data, s.data)
+
+	s.data = data
+}
+
+// All returns an iterator over the elements of the stack, from the top to the bottom.
+//
+// The stack should not be modified during the iteration.
+func (s *sliceStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.data) - 1; i >= 0; i-- {
+			if !yield(s.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns a new slice holding the elements of the stack, from the bottom to the top.
+//
+// O(n)
+func (s *sliceStack[T]) ToSlice() []T {
+	return s.AppendTo(make([]T, 0, len(s.data)))
+}
+
+// AppendTo appends the elements of the stack to dst, from the bottom to the top,
+// and returns the extended slice.
+//
+// O(n)
+func (s *sliceStack[T]) AppendTo(dst []T) []T {
+	return append(dst, s.data...)
+}
+
+// Option configures a stack created by NewSliceStack.
+type Option func(*options)
+
+// options holds the configuration of a stack created by NewSliceStack.
+type options struct {
+	capacity int
+}
+
+// WithCapacity preallocates room for n elements in the stack.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// NewSliceStack creates and returns a new stack implemented with a slice.
+//
+// Example usage:
+// - NewSliceStack[int]()
+// - NewSliceStack[int](WithCapacity(1024))
+func NewSliceStack[T any](opts ...Option) SliceStack[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &sliceStack[T]{
+		data: make([]T, 0, max(o.capacity, 0)),
+	}
+}
@@ -1,6 +1,10 @@
 package stack
 
-import "github.com/TheFeij/go-collections/linkedlist"
+import (
+	"github.com/TheFeij/go-collections/linkedlist"
+	"iter"
+	"slices"
+)
 
 // stack is a struct representing a generic stack data structure.
 type stack[T any] struct {
@@ -41,6 +45,39 @@ func (s *stack[T]) Peek() (t T, ok bool) {
 	return
 }
 
+// All returns an iterator over the elements of the stack, from the top to the bottom.
+//
+// The iterator panics if the stack is modified during the iteration.
+func (s *stack[T]) All() iter.Seq[T] {
+	return s.list.Values()
+}
+
+// ToSlice returns a new slice holding the elements of the stack, from the bottom to the top.
+//
+// O(n)
+func (s *stack[T]) ToSlice() []T {
+	return s.AppendTo(make([]T, 0, s.list.Size()))
+}
+
+// AppendTo appends the elements of the stack to dst, from the bottom to the top,
+// and returns the extended slice.
+//
+// the list holds the top element first, so the elements are written backwards
+// into the extended part of dst
+// O(n)
+func (s *stack[T]) AppendTo(dst []T) []T {
+	size := s.list.Size()
+	dst = slices.Grow(dst, size)[:len(dst)+size]
+
+	i := len(dst)
+	for t := range s.list.Values() {
+		i--
+		dst[i] = t
+	}
+
+	return dst
+}
+
 // NewStack creates and returns a new stack.
 // It initializes the underlying linked list with a new singly linked list.
 func NewStack[T any]() Stack[T] {
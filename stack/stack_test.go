@@ -1,65 +1,335 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"github.com/stretchr/testify/require"
+	"slices"
 	"testing"
 )
 
+// stacks is the list of stack implementations that the tests run against
+var stacks = []struct {
+	name     string
+	newStack func() Stack[any]
+}{
+	{
+		name:     "linked list stack",
+		newStack: NewStack[any],
+	},
+	{
+		name: "slice stack",
+		newStack: func() Stack[any] {
+			return NewSliceStack[any]()
+		},
+	},
+}
+
 func TestNewStack(t *testing.T) {
-	s := NewStack[any]()
+	for _, stack := range stacks {
+		t.Run(stack.name, func(t *testing.T) {
+			s := stack.newStack()
 
-	require.NotNil(t, s)
-	require.NotEmpty(t, s)
-	require.Equal(t, 0, s.Size())
+			require.NotNil(t, s)
+			require.NotEmpty(t, s)
+			require.Equal(t, 0, s.Size())
 
-	// no value to peep
-	value, ok := s.Peek()
-	require.Zero(t, value)
-	require.False(t, ok)
+			// no value to peep
+			value, ok := s.Peek()
+			require.Zero(t, value)
+			require.False(t, ok)
 
-	// no value to pop
-	value, ok = s.Pop()
-	require.Zero(t, value)
-	require.False(t, ok)
+			// no value to pop
+			value, ok = s.Pop()
+			require.Zero(t, value)
+			require.False(t, ok)
+		})
+	}
 }
 
 func TestStack_Push_Pop(t *testing.T) {
-	s := NewStack[any]()
+	for _, stack := range stacks {
+		t.Run(stack.name, func(t *testing.T) {
+			s := stack.newStack()
+
+			const numberOfElements = 5
+			for i := 0; i < numberOfElements; i++ {
+				s.Push(i)
+				require.Equal(t, i+1, s.Size())
+			}
+
+			require.Equal(t, numberOfElements, s.Size())
+
+			for i := 0; i < numberOfElements; i++ {
+				value, ok := s.Pop()
+				require.True(t, ok)
+				require.Equal(t, numberOfElements-i-1, value)
+				require.Equal(t, numberOfElements-i-1, s.Size())
+			}
+
+			value, ok := s.Pop()
+			require.False(t, ok)
+			require.Zero(t, value)
+			require.Equal(t, 0, s.Size())
+		})
+	}
+}
+
+func TestStack_Peek(t *testing.T) {
+	for _, stack := range stacks {
+		t.Run(stack.name, func(t *testing.T) {
+			s := stack.newStack()
 
-	const numberOfElements = 5
-	for i := 0; i < numberOfElements; i++ {
-		s.Push(i)
-		require.Equal(t, i+1, s.Size())
+			value, ok := s.Peek()
+			require.False(t, ok)
+			require.Zero(t, value)
+
+			const numberOfElements = 5
+			for i := 0; i < numberOfElements; i++ {
+				s.Push(i)
+
+				value, ok = s.Peek()
+				require.True(t, ok)
+				require.Equal(t, i, value)
+			}
+		})
 	}
+}
 
-	require.Equal(t, numberOfElements, s.Size())
+func TestSliceStack_Capacity(t *testing.T) {
+	t.Run("WithCapacity", func(t *testing.T) {
+		s := NewSliceStack[int](WithCapacity(100))
+		require.Equal(t, 100, s.Cap())
+		require.Equal(t, 0, s.Size())
 
-	for i := 0; i < numberOfElements; i++ {
-		value, ok := s.Pop()
+		s = NewSliceStack[int](WithCapacity(-1))
+		require.Equal(t, 0, s.Cap())
+	})
+	t.Run("Grow", func(t *testing.T) {
+		s := NewSliceStack[int]()
+		s.Push(1)
+		s.Push(2)
+
+		s.Grow(100)
+		require.GreaterOrEqual(t, s.Cap(), 102)
+		require.Equal(t, 2, s.Size())
+
+		capacity := s.Cap()
+		s.Grow(10)
+		require.Equal(t, capacity, s.Cap())
+
+		s.Grow(-1)
+		require.Equal(t, capacity, s.Cap())
+
+		value, ok := s.Peek()
 		require.True(t, ok)
-		require.Equal(t, numberOfElements-i-1, value)
-		require.Equal(t, numberOfElements-i-1, s.Size())
+		require.Equal(t, 2, value)
+	})
+	t.Run("Clip", func(t *testing.T) {
+		s := NewSliceStack[int](WithCapacity(100))
+		for i := 0; i < 10; i++ {
+			s.Push(i)
+		}
+
+		s.Clip()
+		require.Equal(t, 10, s.Cap())
+		require.Equal(t, 10, s.Size())
+
+		for i := 9; i >= 0; i-- {
+			value, ok := s.Pop()
+			require.True(t, ok)
+			require.Equal(t, i, value)
+		}
+	})
+}
+
+func BenchmarkStack(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		newStack func() Stack[int]
+	}{
+		{name: "linked list stack", newStack: NewStack[int]},
+		{name: "slice stack", newStack: func() Stack[int] { return NewSliceStack[int]() }},
+		{name: "preallocated slice stack", newStack: func() Stack[int] { return NewSliceStack[int](WithCapacity(1024)) }},
+	}
+
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name+"/Push Pop", func(b *testing.B) {
+			s := benchmark.newStack()
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s.Push(i)
+				s.Pop()
+			}
+		})
+		b.Run(benchmark.name+"/Push All Then Pop All", func(b *testing.B) {
+			const batchSize = 1024
+			s := benchmark.newStack()
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < batchSize; j++ {
+					s.Push(j)
+				}
+				for j := 0; j < batchSize; j++ {
+					s.Pop()
+				}
+			}
+		})
+	}
+}
+
+func TestStack_All(t *testing.T) {
+	for _, stack := range stacks {
+		t.Run(stack.name, func(t *testing.T) {
+			s := stack.newStack()
+			require.Empty(t, slices.Collect(s.All()))
+
+			for i := 0; i < 5; i++ {
+				s.Push(i)
+			}
+
+			require.Equal(t, []any{4, 3, 2, 1, 0}, slices.Collect(s.All()))
+			require.Equal(t, 5, s.Size())
+
+			var values []any
+			for value := range s.All() {
+				if len(values) == 2 {
+					break
+				}
+				values = append(values, value)
+			}
+			require.Equal(t, []any{4, 3}, values)
+		})
 	}
+}
+
+func TestFromSeq(t *testing.T) {
+	s := FromSeq(slices.Values([]int{1, 2, 3}))
+	require.Equal(t, 3, s.Size())
+	require.Equal(t, []int{3, 2, 1}, slices.Collect(s.All()))
 
 	value, ok := s.Pop()
-	require.False(t, ok)
-	require.Zero(t, value)
-	require.Equal(t, 0, s.Size())
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+
+	require.Zero(t, FromSeq(slices.Values([]int{})).Size())
 }
 
-func TestStack_Peek(t *testing.T) {
-	s := NewStack[any]()
+func TestStack_ToSlice(t *testing.T) {
+	for _, stack := range stacks {
+		t.Run(stack.name, func(t *testing.T) {
+			s := stack.newStack()
+			require.Equal(t, []any{}, s.ToSlice())
+			require.Nil(t, s.AppendTo(nil))
 
-	value, ok := s.Peek()
-	require.False(t, ok)
-	require.Zero(t, value)
+			for i := 0; i < 5; i++ {
+				s.Push(i)
+			}
 
-	const numberOfElements = 5
-	for i := 0; i < numberOfElements; i++ {
-		s.Push(i)
+			// the elements are in push order, the opposite of All
+			require.Equal(t, []any{0, 1, 2, 3, 4}, s.ToSlice())
+			require.Equal(t, []any{"a", 0, 1, 2, 3, 4}, s.AppendTo([]any{"a"}))
+			require.Equal(t, 5, s.Size())
 
-		value, ok = s.Peek()
-		require.True(t, ok)
-		require.Equal(t, i, value)
+			// the returned slice is not shared with the stack
+			values := s.ToSlice()
+			values[4] = 10
+			top, ok := s.Peek()
+			require.True(t, ok)
+			require.Equal(t, 4, top)
+		})
+	}
+}
+
+func TestFrom_FromSlice(t *testing.T) {
+	s := From(1, 2, 3)
+	require.Equal(t, []int{1, 2, 3}, s.ToSlice())
+
+	value, ok := s.Pop()
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+
+	// the input slice is copied
+	data := []int{1, 2, 3}
+	s = FromSlice(data)
+	data[2] = 10
+	s.Push(4)
+	require.Equal(t, []int{1, 2, 3, 4}, s.ToSlice())
+
+	// a stack converted to a slice and back is unchanged
+	require.Equal(t, s.ToSlice(), FromSlice(s.ToSlice()).ToSlice())
+
+	require.Zero(t, From[int]().Size())
+	require.Zero(t, FromSlice[int](nil).Size())
+}
+
+func TestStack_Encoding(t *testing.T) {
+	constructors := []struct {
+		name     string
+		newStack func() Stack[int]
+	}{
+		{
+			name:     "linked list stack",
+			newStack: NewStack[int],
+		},
+		{
+			name: "slice stack",
+			newStack: func() Stack[int] {
+				return NewSliceStack[int]()
+			},
+		},
+	}
+
+	for _, constructor := range constructors {
+		t.Run(constructor.name, func(t *testing.T) {
+			s := constructor.newStack()
+			for i := 0; i < 5; i++ {
+				s.Push(i)
+			}
+
+			// the elements are encoded in push order, so the top is restored
+			data, err := json.Marshal(s)
+			require.NoError(t, err)
+			require.JSONEq(t, `[0, 1, 2, 3, 4]`, string(data))
+
+			decoded := constructor.newStack()
+			decoded.Push(100)
+			require.NoError(t, json.Unmarshal(data, decoded))
+			require.Equal(t, []int{4, 3, 2, 1, 0}, slices.Collect(decoded.All()))
+
+			// null and invalid data leave the stack unchanged
+			require.NoError(t, json.Unmarshal([]byte(`null`), decoded))
+			require.Error(t, json.Unmarshal([]byte(`{}`), decoded))
+			require.Equal(t, []int{0, 1, 2, 3, 4}, decoded.ToSlice())
+
+			var buffer bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buffer).Encode(s))
+			decoded = constructor.newStack()
+			require.NoError(t, gob.NewDecoder(&buffer).Decode(decoded))
+			require.Equal(t, []int{0, 1, 2, 3, 4}, decoded.ToSlice())
+
+			binary, err := s.MarshalBinary()
+			require.NoError(t, err)
+			decoded = constructor.newStack()
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			require.Equal(t, []int{0, 1, 2, 3, 4}, decoded.ToSlice())
+			require.Error(t, decoded.UnmarshalBinary([]byte("invalid")))
+			require.Equal(t, []int{0, 1, 2, 3, 4}, decoded.ToSlice())
+
+			value, ok := decoded.Pop()
+			require.True(t, ok)
+			require.Equal(t, 4, value)
+
+			// an empty stack encodes to an empty one
+			binary, err = constructor.newStack().MarshalBinary()
+			require.NoError(t, err)
+			require.NoError(t, decoded.UnmarshalBinary(binary))
+			require.Zero(t, decoded.Size())
+			decoded.Push(1)
+			require.Equal(t, []int{1}, decoded.ToSlice())
+		})
 	}
 }